@@ -0,0 +1,151 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type ColorblindPreset = uint8
+
+const (
+	NoColorblindPreset     ColorblindPreset = iota
+	RedGreenSafe           ColorblindPreset = iota // protanopia and deuteranopia
+	BlueYellowSafe         ColorblindPreset = iota // tritanopia
+	Monochrome             ColorblindPreset = iota // told apart by brightness alone
+	TotalColorblindPresets ColorblindPreset = iota
+)
+
+var ColorblindPresetNames = [TotalColorblindPresets]string{
+	NoColorblindPreset: "off",
+	RedGreenSafe:       "red-green",
+	BlueYellowSafe:     "blue-yellow",
+	Monochrome:         "monochrome",
+}
+
+// the presets are picked from the Okabe-Ito palette, player one first
+var ColorblindPalettes = [TotalColorblindPresets][TotalPlayerCount]PlayerColorPalette{
+	RedGreenSafe: {
+		PlayerOne: {
+			primaryColor:   rl.NewColor(0, 114, 178, 255),
+			outerRingColor: rl.NewColor(0, 70, 120, 255),
+			lifeColor:      rl.NewColor(255, 250, 255, 255),
+			rocketColor:    rl.NewColor(86, 180, 233, 255),
+		},
+		PlayerTwo: {
+			primaryColor:   rl.NewColor(230, 159, 0, 255),
+			outerRingColor: rl.NewColor(150, 100, 0, 255),
+			lifeColor:      rl.NewColor(255, 250, 255, 255),
+			rocketColor:    rl.NewColor(240, 228, 66, 255),
+		},
+	},
+	BlueYellowSafe: {
+		PlayerOne: {
+			primaryColor:   rl.NewColor(213, 94, 0, 255),
+			outerRingColor: rl.NewColor(140, 55, 0, 255),
+			lifeColor:      rl.NewColor(255, 250, 255, 255),
+			rocketColor:    rl.NewColor(230, 130, 60, 255),
+		},
+		PlayerTwo: {
+			primaryColor:   rl.NewColor(0, 158, 115, 255),
+			outerRingColor: rl.NewColor(0, 95, 70, 255),
+			lifeColor:      rl.NewColor(255, 250, 255, 255),
+			rocketColor:    rl.NewColor(90, 200, 160, 255),
+		},
+	},
+	Monochrome: {
+		PlayerOne: {
+			primaryColor:   rl.NewColor(235, 235, 235, 255),
+			outerRingColor: rl.NewColor(150, 150, 150, 255),
+			lifeColor:      rl.NewColor(30, 30, 30, 255),
+			rocketColor:    rl.NewColor(255, 255, 255, 255),
+		},
+		PlayerTwo: {
+			primaryColor:   rl.NewColor(35, 35, 35, 255),
+			outerRingColor: rl.NewColor(95, 95, 95, 255),
+			lifeColor:      rl.NewColor(255, 255, 255, 255),
+			rocketColor:    rl.NewColor(70, 70, 70, 255),
+		},
+	},
+}
+
+var HighContrastBackground = rl.NewColor(16, 18, 22, 255)
+
+const minTextScale float32 = 0.75
+const maxTextScale float32 = 1.25
+
+// AccessibilitySettings - part of the config, applied while drawing so they can be changed mid-match
+type AccessibilitySettings struct {
+	ColorblindPreset ColorblindPreset `json:"colorblindPreset"`
+	PlayerGlyphs     bool             `json:"playerGlyphs"` // a shape per player in the middle of the stones
+	HighContrast     bool             `json:"highContrast"`
+	ReduceMotion     bool             `json:"reduceMotion"` // fewer shards and particles
+	TextScale        float32          `json:"textScale"`
+}
+
+func defaultAccessibilitySettings() AccessibilitySettings {
+	return AccessibilitySettings{
+		TextScale: 1,
+	}
+}
+
+// lookOf - the colors and shapes a player's stones, shards and rockets are drawn with
+func (level *Level) lookOf(player Player) PlayerSettings {
+	settings := level.playerSettings[player]
+	accessibility := config.Accessibility
+
+	if accessibility.ColorblindPreset != NoColorblindPreset && accessibility.ColorblindPreset < TotalColorblindPresets {
+		palette := ColorblindPalettes[accessibility.ColorblindPreset][player]
+		settings.primaryColor = palette.primaryColor
+		settings.outerRingColor = palette.outerRingColor
+		settings.lifeColor = palette.lifeColor
+		settings.rocketColor = palette.rocketColor
+	}
+
+	if accessibility.PlayerGlyphs {
+		// the glyph takes the emblem's place
+		settings.emblem = NoEmblem
+	}
+
+	return settings
+}
+
+// drawPlayerGlyph - a triangle for player one and a square for player two, so the colors aren't needed
+func drawPlayerGlyph(pos rl.Vector2, radius float32, player Player, color rl.Color) {
+	if !config.Accessibility.PlayerGlyphs {
+		return
+	}
+
+	if player == PlayerOne {
+		rl.DrawPoly(pos, 3, radius*0.4, -90, color)
+	} else {
+		rl.DrawPoly(pos, 4, radius*0.38, 45, color)
+	}
+}
+
+// fieldColors - the background and the line color of the field
+func (level *Level) fieldColors() (rl.Color, rl.Color) {
+	if config.Accessibility.HighContrast {
+		return HighContrastBackground, dimWhite(255)
+	}
+	return level.levelSettings.backgroundColor, dimWhite(125)
+}
+
+// shardStep - the angle step of the shard bursts, reduce motion spreads fewer of them
+func shardStep() float32 {
+	if config.Accessibility.ReduceMotion {
+		return 4
+	}
+	return 0.5
+}
+
+// particleCount - the particles of a burst, reduce motion cuts most of them
+func particleCount(n int) int {
+	if config.Accessibility.ReduceMotion {
+		return n / 5
+	}
+	return n
+}
+
+// uiFontSize - the base font size on the canvas, everything else is a fraction of it
+func uiFontSize(screenWidth float32) float32 {
+	return screenWidth * 0.25 * config.Accessibility.TextScale
+}
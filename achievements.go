@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const achievementsFileName = "achievements.json"
+
+type AchievementId = uint8
+
+const (
+	FirstWin         AchievementId = iota
+	HatTrick         AchievementId = iota
+	Flawless         AchievementId = iota
+	DoubleCushion    AchievementId = iota
+	LifeTiebreak     AchievementId = iota
+	TotalAchievement AchievementId = iota
+)
+
+type Achievement struct {
+	key         string // stable name used in the save file
+	title       string
+	description string
+}
+
+var AchievementList = [TotalAchievement]Achievement{
+	FirstWin:      {"first_win", "first of many", "win a match"},
+	HatTrick:      {"hat_trick", "hat trick", "knock off three stones with one shot"},
+	Flawless:      {"flawless", "flawless", "win without losing a stone"},
+	DoubleCushion: {"double_cushion", "double cushion", "win a bordered match with a two-cushion bank shot"},
+	LifeTiebreak:  {"life_tiebreak", "photo finish", "win time-limit mode on the life tiebreak"},
+}
+
+// AchievementStore - the unlocked achievements, kept across sessions
+type AchievementStore struct {
+	unlocked [TotalAchievement]bool
+	toasts   []toast
+}
+
+type achievementsFile struct {
+	Unlocked map[string]time.Time `json:"unlocked"`
+}
+
+var achievements = AchievementStore{}
+
+func (store *AchievementStore) load() {
+	file := achievementsFile{}
+	if err := loadJSON(achievementsFileName, &file); err != nil {
+		rl.TraceLog(rl.LogInfo, "achievements could not be loaded: %v", err)
+		return
+	}
+
+	for id, achievement := range AchievementList {
+		_, store.unlocked[id] = file.Unlocked[achievement.key]
+	}
+}
+
+func (store *AchievementStore) save() {
+	// keep the unlock dates of the existing ones
+	file := achievementsFile{}
+	_ = loadJSON(achievementsFileName, &file)
+	if file.Unlocked == nil {
+		file.Unlocked = map[string]time.Time{}
+	}
+
+	for id, achievement := range AchievementList {
+		if _, ok := file.Unlocked[achievement.key]; store.unlocked[id] && !ok {
+			file.Unlocked[achievement.key] = time.Now()
+		}
+	}
+
+	if err := saveJSON(achievementsFileName, file); err != nil {
+		rl.TraceLog(rl.LogWarning, "achievements could not be saved: %v", err)
+	}
+}
+
+func (store *AchievementStore) isUnlocked(id AchievementId) bool {
+	return store.unlocked[id]
+}
+
+func (store *AchievementStore) unlock(id AchievementId) {
+	if store.unlocked[id] {
+		return
+	}
+	store.unlocked[id] = true
+	store.toasts = append(store.toasts, newToast(fmt.Sprintf("achievement unlocked: %s", AchievementList[id].title)))
+	store.save()
+}
+
+func (store *AchievementStore) countUnlocked() int {
+	count := 0
+	for _, unlocked := range store.unlocked {
+		if unlocked {
+			count++
+		}
+	}
+	return count
+}
+
+// achievementTracker - per-match bookkeeping fed by the match log events
+type achievementTracker struct {
+	shooter        Player
+	shotStoneId    uint8
+	shotInProgress bool
+	// events of the current shot
+	enemiesKnocked   uint8
+	cushions         uint8 // wall hits before the shot stone touched another stone
+	touchedStone     bool
+	lastShotCushions uint8
+	// the whole match
+	stonesLost [TotalPlayerCount]uint8
+}
+
+func newAchievementTracker() achievementTracker {
+	return achievementTracker{}
+}
+
+// isTracked - the main menu demo and the cpu-only matches do not count
+func isTracked(level *Level, player Player) bool {
+	return level.levelSettings.sceneId != Main && !level.playerSettings[player].isCpu
+}
+
+func (tracker *achievementTracker) observe(level *Level, event MatchEvent) {
+	switch event.kind {
+	case ShotFired:
+		tracker.shooter = event.playerId
+		tracker.shotStoneId = event.stoneId
+		tracker.shotInProgress = true
+		tracker.enemiesKnocked = 0
+		tracker.cushions = 0
+		tracker.touchedStone = false
+	case WallHit:
+		if tracker.shotInProgress && !tracker.touchedStone && event.stoneId == tracker.shotStoneId {
+			tracker.cushions++
+		}
+	case StoneCollided:
+		if event.stoneId == tracker.shotStoneId || event.otherId == tracker.shotStoneId {
+			if !tracker.touchedStone {
+				tracker.lastShotCushions = tracker.cushions
+			}
+			tracker.touchedStone = true
+		}
+	case StoneDied:
+		tracker.stonesLost[event.playerId]++
+
+		if tracker.shotInProgress && event.playerId != tracker.shooter {
+			tracker.enemiesKnocked++
+			if tracker.enemiesKnocked >= 3 && isTracked(level, tracker.shooter) {
+				achievements.unlock(HatTrick)
+			}
+		}
+	case MatchEnded:
+		tracker.matchEnded(level, event.playerId)
+	}
+}
+
+// shotJudged - the stones stopped after the shot, what happens next isn't its doing
+func (tracker *achievementTracker) shotJudged() {
+	tracker.shotInProgress = false
+}
+
+func (tracker *achievementTracker) matchEnded(level *Level, winner Player) {
+	if !isTracked(level, winner) || level.finishReason == FinishedByForfeit {
+		return
+	}
+
+	achievements.unlock(FirstWin)
+
+	if tracker.stonesLost[winner] == 0 {
+		achievements.unlock(Flawless)
+	}
+
+	wonByBankShot := tracker.shotInProgress &&
+		tracker.shooter == winner &&
+		tracker.touchedStone &&
+		tracker.lastShotCushions >= 2 &&
+		tracker.enemiesKnocked > 0
+
+	if level.levelSettings.isBordered && level.finishReason == FinishedByKnockout && wonByBankShot {
+		achievements.unlock(DoubleCushion)
+	}
+
+	if level.levelSettings.isTimed && level.finishReason == FinishedByLife {
+		achievements.unlock(LifeTiebreak)
+	}
+}
+
+type toast struct {
+	text string
+	life float32
+}
+
+const toastDuration = 3.5
+
+func newToast(text string) toast {
+	return toast{text: text, life: toastDuration}
+}
+
+func (store *AchievementStore) updateToasts() {
+	if len(store.toasts) == 0 {
+		return
+	}
+	// one toast at a time, the rest wait in the queue
+	store.toasts[0].life -= rl.GetFrameTime()
+	if store.toasts[0].life <= 0 {
+		store.toasts = store.toasts[1:]
+	}
+}
+
+func (store *AchievementStore) drawToasts(window *Window) {
+	if len(store.toasts) == 0 {
+		return
+	}
+
+	screenWidth, screenHeight := window.GetScreenDimensions()
+	t := store.toasts[0]
+
+	// slide in from the top and fade out at the end
+	alpha := rl.Clamp(t.life, 0, 1)
+	slide := rl.Clamp((toastDuration-t.life)*4, 0, 1)
+
+	fontSize := FontSize / 12
+	measured := rl.MeasureTextEx(rl.GetFontDefault(), t.text, fontSize, fontSize/10)
+	padding := fontSize * 0.5
+
+	box := rl.NewRectangle(
+		(screenWidth-measured.X)/2-padding,
+		screenHeight*0.03*slide-(1-slide)*(measured.Y+2*padding),
+		measured.X+2*padding,
+		measured.Y+2*padding,
+	)
+
+	rl.DrawRectangleRec(box, rl.ColorAlpha(BG_COLOR, alpha))
+	rl.DrawRectangleLinesEx(box, 4, rl.ColorAlpha(dimWhite(200), alpha))
+	rl.DrawTextEx(
+		rl.GetFontDefault(),
+		t.text,
+		rl.NewVector2(box.X+padding, box.Y+padding),
+		fontSize,
+		fontSize/10,
+		rl.ColorAlpha(dimWhite(255), alpha),
+	)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SceneAchievements struct {
+	nextSceneId SceneId
+	backButton  buttonRectangle
+}
+
+func NewSceneAchievements() SceneAchievements {
+	return SceneAchievements{}
+}
+
+func (scene *SceneAchievements) GetId() SceneId {
+	return Achievements
+}
+
+func (scene *SceneAchievements) Init(data any, window *Window) {
+	scene.nextSceneId = scene.GetId()
+
+	screenWidth, screenHeight := window.GetScreenDimensions()
+
+	back := rl.MeasureTextEx(rl.GetFontDefault(), "back", FontSize/7, 10)
+	scene.backButton = buttonRectangle{
+		text:         "back",
+		rectangle:    rl.NewRectangle((screenWidth-back.X)/2, screenHeight*0.85, back.X, back.Y),
+		fontSize:     FontSize / 7,
+		targetScene:  Main,
+		interactable: true,
+	}
+}
+
+func (scene *SceneAchievements) HandleUserInput(window *Window) {
+	clicked := (rl.IsMouseButtonReleased(rl.MouseButtonLeft) || touchInput.tapped()) && scene.backButton.active
+	// there's only one button, so any confirm or back from the keyboard or a pad takes us out
+	if clicked || menuConfirmPressed() || menuBackPressed() {
+		scene.nextSceneId = scene.backButton.targetScene
+	}
+}
+
+func (scene *SceneAchievements) Update(window *Window) (SceneId, any) {
+	scene.backButton.active = touchInput.isOver(scene.backButton.rectangle)
+	return scene.nextSceneId, nil
+}
+
+func (scene *SceneAchievements) Draw(window *Window) {
+	rl.ClearBackground(BG_COLOR)
+
+	screenWidth, screenHeight := window.GetScreenDimensions()
+	defaultFont := rl.GetFontDefault()
+
+	title := fmt.Sprintf("achievements %d/%d", achievements.countUnlocked(), TotalAchievement)
+	titleSize := FontSize / 5
+	measured := rl.MeasureTextEx(defaultFont, title, titleSize, 10)
+	rl.DrawTextEx(defaultFont, title, rl.NewVector2((screenWidth-measured.X)/2, screenHeight*0.1), titleSize, 10, dimWhite(120))
+
+	rowHeight := screenHeight * 0.11
+	y := screenHeight * 0.28
+	x := screenWidth * 0.25
+	badgeRadius := rowHeight * 0.3
+
+	for id, achievement := range AchievementList {
+		unlocked := achievements.isUnlocked(AchievementId(id))
+
+		dimLevel := uint8(60)
+		if unlocked {
+			dimLevel = 230
+		}
+
+		center := rl.NewVector2(x, y+rowHeight*0.4)
+		if unlocked {
+			rl.DrawCircleV(center, badgeRadius, HumanPlayerPalette1.primaryColor)
+			rl.DrawRing(center, badgeRadius*0.8, badgeRadius*1.01, 0, 360, 0, HumanPlayerPalette1.outerRingColor)
+		}
+		rl.DrawRing(center, badgeRadius*1.1, badgeRadius*1.3, 0, 360, 0, dimWhite(dimLevel))
+
+		rl.DrawTextEx(defaultFont, achievement.title, rl.NewVector2(x+badgeRadius*2, y), FontSize/10, 5, dimWhite(dimLevel))
+		rl.DrawTextEx(defaultFont, achievement.description, rl.NewVector2(x+badgeRadius*2, y+rowHeight*0.45), FontSize/16, 3, dimWhite(dimLevel))
+
+		y += rowHeight
+	}
+
+	dimLevel := uint8(60)
+	if scene.backButton.active {
+		dimLevel = 255
+	}
+	rl.DrawTextEx(
+		defaultFont,
+		scene.backButton.text,
+		rl.NewVector2(scene.backButton.rectangle.X, scene.backButton.rectangle.Y),
+		scene.backButton.fontSize,
+		10,
+		dimWhite(dimLevel),
+	)
+}
+
+func (scene *SceneAchievements) Teardown(window *Window) {
+
+}
@@ -10,6 +10,140 @@ import (
 type searchPair struct {
 	actor, target *Stone
 	score         float32
+	aim           rl.Vector2 // the actor is shot at this, the target itself or a portal leading to it
+	exit          rl.Vector2 // where the actor comes out of the portal
+	viaPortal     bool
+	travel        float32 // how far the actor goes to get to the target
+}
+
+// legs - the straight lines the actor goes along to get to the target
+func (pair searchPair) legs() [][2]rl.Vector2 {
+	if pair.viaPortal {
+		return [][2]rl.Vector2{{pair.actor.pos, pair.aim}, {pair.exit, pair.target.pos}}
+	}
+	return [][2]rl.Vector2{{pair.actor.pos, pair.target.pos}}
+}
+
+// portalShots - the shots going through a portal that come out heading for the target.
+// the actor is shot at the center of the portal
+func portalShots(level *Level, actor, target *Stone) []searchPair {
+	shots := []searchPair{}
+
+	for _, portals := range level.levelSettings.portals {
+		for entry, portal := range portals.ends {
+			in := rl.Vector2Normalize(rl.Vector2Subtract(portal.pos, actor.pos))
+			out := rl.Vector2Normalize(portals.throughPortal(entry, in))
+			exit := portals.ends[1-entry].exitPos(out)
+
+			if _, ok := rayToCircle(exit, out, target.pos, target.radius); !ok {
+				continue
+			}
+
+			shots = append(shots, searchPair{
+				actor:     actor,
+				target:    target,
+				aim:       portal.pos,
+				exit:      exit,
+				viaPortal: true,
+				travel:    rl.Vector2Distance(actor.pos, portal.pos) + rl.Vector2Distance(exit, target.pos),
+			})
+		}
+	}
+
+	return shots
+}
+
+// cpuPull - where the actor is pulled back to, to be shot at the aim hard enough to go the distance
+func (level *Level) cpuPull(actor *Stone, aim rl.Vector2, travel float32) rl.Vector2 {
+	pull := rl.Vector2Scale(rl.Vector2Normalize(rl.Vector2Subtract(aim, actor.pos)), travel)
+	// pulled less over ice and more over sand
+	pull = rl.Vector2Scale(pull, 1/level.pathReach(actor.pos, aim))
+	// and more for the heavy stones that launch slower, less for the light ones
+	pull = rl.Vector2Scale(pull, 1/kindInfo(actor).launchSpeed)
+	pull = rl.Vector2ClampValue(pull, 0.0, MaxPullLengthAllowed)
+	return rl.Vector2Subtract(actor.pos, pull)
+}
+
+// the aim is swept this far to both sides of the straight one, in degrees
+const (
+	curvedAimSweep = 40
+	curvedAimStep  = 4
+)
+
+// curvedAim - the wells bend the shots, so the aim is swept around the straight one
+// and the closest one whose simulated shot hits the target is taken. false when none of them does
+func curvedAim(level *Level, pair *searchPair) bool {
+	actor := pair.actor
+	straight := rl.Vector2Subtract(pair.aim, actor.pos)
+
+	bounces := 0
+	if level.levelSettings.isBordered {
+		bounces = 1
+	}
+
+	for offset := 0; offset <= curvedAimSweep; offset += curvedAimStep {
+		for _, side := range []float32{1, -1} {
+			aim := rl.Vector2Add(actor.pos, rl.Vector2Rotate(straight, side*float32(offset)*rl.Deg2rad))
+			velocity := launchVelocity(actor, level.cpuPull(actor, aim, pair.travel))
+
+			if level.simulateShot(actor, velocity, bounces).contact == pair.target {
+				pair.aim = aim
+				return true
+			}
+			if offset == 0 {
+				break
+			}
+		}
+	}
+
+	return false
+}
+
+// lineOfSight - whether other stones are in the way of the actor going from one point to the other,
+// and whether one of them is its own
+func lineOfSight(level *Level, actor, target *Stone, from, to rl.Vector2, toRadius float32) (bool, bool) {
+	me := actor.playerId
+
+	ssOrigin := rl.Vector2Subtract(from, to)
+	angle := 2 * rl.Vector2LineAngle(rl.Vector2Normalize(ssOrigin), rl.NewVector2(1, 0))
+
+	aTop := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, -actor.radius), -angle), from)
+	aBottom := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, actor.radius), -angle), from)
+
+	tTop := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, -toRadius), -angle), to)
+	tBottom := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, toRadius), -angle), to)
+
+	hitsOwn := false
+	richochets := false
+
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.isDead || (stone == actor || stone == target) {
+			continue
+		}
+		// line 1 check aTop - tTop
+		if rl.CheckCollisionCircleLine(stone.pos, stone.radius, aTop, tTop) {
+			hitsOwn = stone.playerId == me
+			richochets = true
+		}
+		// line 2 check aBottom - tBottom
+		if rl.CheckCollisionCircleLine(stone.pos, stone.radius, aBottom, tBottom) {
+			hitsOwn = stone.playerId == me
+			richochets = true
+		}
+
+		// line 3 check center to center
+		if rl.CheckCollisionCircleLine(stone.pos, stone.radius, from, to) {
+			hitsOwn = stone.playerId == me
+			richochets = true
+		}
+
+		if hitsOwn && richochets {
+			break
+		}
+	}
+
+	return hitsOwn, richochets
 }
 
 func compareSearchPairs(p1, p2 searchPair) int {
@@ -23,14 +157,19 @@ func compareSearchPairs(p1, p2 searchPair) int {
 // / - life state of the hitting stone
 // / - whether own stone will be hit in the process
 // / - whether stone will richochet
-func cpuSearchBestOption(level *Level, window *Window) (*Stone, *Stone) {
+// / - power-ups on the way and double damage charges
+// / - the surfaces the shot goes over
+// / - the pits and open sides the stones could be knocked into
+// / - the portals it can shoot through
+// / - the wells bending the shots, by simulating them
+func cpuSearchBestOption(level *Level, window *Window) (searchPair, bool) {
 	me := level.playerTurn
 
 	searchPairs := []searchPair{}
 
 	for i := range level.stones {
 		actor := &level.stones[i]
-		if actor.isDead || actor.playerId != me {
+		if !actor.canBePlayed() || actor.playerId != me {
 			continue
 		}
 		for j := range level.stones {
@@ -46,7 +185,10 @@ func cpuSearchBestOption(level *Level, window *Window) (*Stone, *Stone) {
 			searchPairs = append(searchPairs, searchPair{
 				actor:  actor,
 				target: target,
+				aim:    target.pos,
+				travel: rl.Vector2Distance(actor.pos, target.pos),
 			})
+			searchPairs = append(searchPairs, portalShots(level, actor, target)...)
 		}
 	}
 
@@ -56,46 +198,26 @@ func cpuSearchBestOption(level *Level, window *Window) (*Stone, *Stone) {
 		pair := &(searchPairs[pi])
 		actor, target := pair.actor, pair.target
 
-		ssOrigin := rl.Vector2Subtract(actor.pos, target.pos)
-		angle := 2 * rl.Vector2LineAngle(rl.Vector2Normalize(ssOrigin), rl.NewVector2(1, 0))
-
-		aTop := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, -actor.radius), -angle), actor.pos)
-		aBottom := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, actor.radius), -angle), actor.pos)
-
-		tTop := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, -target.radius), -angle), target.pos)
-		tBottom := rl.Vector2Add(rl.Vector2Rotate(rl.NewVector2(0, target.radius), -angle), target.pos)
-
 		hitsOwn := false
 		richochets := false
+		crossesPowerUp := false
+		crossesPit := false
 
-		for i := range level.stones {
-			stone := &level.stones[i]
-			if stone.isDead || (stone == actor || stone == target) {
-				continue
-			}
-			// line 1 check aTop - tTop
-			if rl.CheckCollisionCircleLine(stone.pos, stone.radius, aTop, tTop) {
-				hitsOwn = stone.playerId == me
-				richochets = true
-			}
-			// line 2 check aBottom - tBottom
-			if rl.CheckCollisionCircleLine(stone.pos, stone.radius, aBottom, tBottom) {
-				hitsOwn = stone.playerId == me
-				richochets = true
-			}
-
-			// line 3 check center to center
-			if rl.CheckCollisionCircleLine(stone.pos, stone.radius, actor.pos, target.pos) {
-				hitsOwn = stone.playerId == me
-				richochets = true
+		for _, leg := range pair.legs() {
+			// the end of a leg is the target or a portal, the actor has to fit through it either way
+			toRadius := actor.radius
+			if leg[1] == target.pos {
+				toRadius = target.radius
 			}
+			legHitsOwn, legRichochets := lineOfSight(level, actor, target, leg[0], leg[1], toRadius)
+			hitsOwn = hitsOwn || legHitsOwn
+			richochets = richochets || legRichochets
 
-			if hitsOwn && richochets {
-				break
-			}
+			crossesPowerUp = crossesPowerUp || level.pathCrossesPowerUp(leg[0], leg[1], actor.radius)
+			crossesPit = crossesPit || level.pathCrossesPit(leg[0], leg[1])
 		}
 
-		distance := rl.Vector2Distance(actor.pos, target.pos) / screenDiagonalSize
+		distance := pair.travel / screenDiagonalSize
 
 		pair.score -= distance
 		if hitsOwn {
@@ -106,20 +228,52 @@ func cpuSearchBestOption(level *Level, window *Window) (*Stone, *Stone) {
 			pair.score += -0.5
 		}
 
-		if actor.life <= 5 {
+		if level.lifeShare(actor) <= 0.05 {
 			pair.score += -0.5
 		}
 
-		if target.life <= 10 {
+		if level.lifeShare(target) <= 0.1 {
 			pair.score += 1
 		}
+
+		// a power-up on the way is picked up for free
+		if crossesPowerUp {
+			pair.score += 0.4
+		}
+
+		// the actor falls in before it gets there, the target may be knocked in
+		legs := pair.legs()
+		lastLeg := legs[len(legs)-1]
+		if crossesPit {
+			pair.score += -1
+		}
+		if level.pitBehind(target.pos, rl.Vector2Subtract(lastLeg[1], lastLeg[0])) {
+			pair.score += 0.6
+		}
+
+		// the aim through a portal is less certain than a straight one
+		if pair.viaPortal {
+			pair.score += -0.2
+		}
+
+		// the wells bend the shot, it's aimed by simulating it
+		if len(level.levelSettings.wells) > 0 && !curvedAim(level, pair) {
+			pair.score += -0.5
+		}
+
+		// sand eats the power of the shot, ice and boost pads carry it
+		pair.score += (min(level.pathReach(actor.pos, pair.aim), 1.5) - 1) * 0.5
+
+		// a double damage charge is best spent on a stone it can finish off
+		if actor.charged && level.lifeShare(target) <= 0.4 {
+			pair.score += 0.5
+		}
 	}
 
 	slices.SortFunc(searchPairs, compareSearchPairs)
 
 	if len(searchPairs) > 0 {
-		pair := searchPairs[0]
-		return pair.actor, pair.target
+		return searchPairs[0], true
 	}
-	return nil, nil
+	return searchPair{}, false
 }
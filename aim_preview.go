@@ -0,0 +1,219 @@
+package main
+
+import (
+	"math"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type Difficulty = uint8
+
+const (
+	Easy              Difficulty = iota // power meter and the path with its first two bounces
+	Normal            Difficulty = iota // power meter and the path with its first bounce
+	Hard              Difficulty = iota // power meter only
+	TotalDifficulties Difficulty = iota
+)
+
+var DifficultyNames = [TotalDifficulties]string{
+	Easy:   "easy",
+	Normal: "normal",
+	Hard:   "hard",
+}
+
+// AimPreview - where the aimed shot is going, as far as the difficulty lets the player know
+type AimPreview struct {
+	path       []rl.Vector2 // the first point is the stone itself
+	contact    *Stone       // the first stone the shot would touch
+	contactPos rl.Vector2   // where the shot stone would be at that moment
+	jumps      []int        // the points of the path it comes out of a portal at, they aren't joined to the one before
+}
+
+// the portals don't count as bounces, this keeps a loop of them from going on forever
+const maxPreviewPortals = 3
+
+// shotStrength - how hard the stone is pulled, 0..1 of MaxPullLengthAllowed
+func (level *Level) shotStrength() float32 {
+	if level.selectedStone == nil {
+		return 0
+	}
+	return rl.Clamp(rl.Vector2Distance(level.aimVectorStart, level.selectedStone.pos)/MaxPullLengthAllowed, 0, 1)
+}
+
+// shotVelocity - the velocity the selected stone is launched with when it's released
+func (level *Level) shotVelocity() rl.Vector2 {
+	return launchVelocity(level.selectedStone, level.aimVectorStart)
+}
+
+// launchVelocity - the velocity a stone pulled back to the point is launched with
+func launchVelocity(stone *Stone, aimVectorStart rl.Vector2) rl.Vector2 {
+	// find the diff between the selected stone and where the mouse is
+	diff := rl.Vector2Subtract(stone.pos, aimVectorStart)
+	strength := rl.Clamp(rl.Vector2Length(diff)/MaxPullLengthAllowed, 0, 1)
+	// the max speed we allow is MaxPushVelocityAllowed,
+	// so we calculate the speed based on the distance from the selected stone
+	// light stones fly off faster, heavy ones slower
+	speed := MaxPushVelocityAllowed * strength * kindInfo(stone).launchSpeed
+	// normalize the diff vector
+	// scale it up based on the speed
+	return rl.Vector2Scale(rl.Vector2Normalize(diff), speed)
+}
+
+// travelDistance - how far a stone launched at the speed gets before the damping stops it
+func travelDistance(speed float32) float32 {
+	if speed <= VelocityThresholdToStop {
+		return 0
+	}
+	// the speed shrinks geometrically every frame, this is the sum of that series
+	return (speed - VelocityThresholdToStop) / (1 - VelocityDampingFactor)
+}
+
+// rayToCircle - the distance along the ray at which it enters the circle
+func rayToCircle(origin, direction, center rl.Vector2, radius float32) (float32, bool) {
+	m := rl.Vector2Subtract(origin, center)
+	b := rl.Vector2DotProduct(m, direction)
+	c := rl.Vector2DotProduct(m, m) - radius*radius
+
+	// starts outside and points away
+	if c > 0 && b > 0 {
+		return 0, false
+	}
+
+	discriminant := b*b - c
+	if discriminant < 0 {
+		return 0, false
+	}
+
+	return max(-b-float32(math.Sqrt(float64(discriminant))), 0), true
+}
+
+// predictShot - follows the shot in straight lines, bouncing off the walls of bordered levels and going through the portals.
+// the other stones are treated as still, the first one touched ends the path
+func (level *Level) predictShot(velocity rl.Vector2, bounces int) AimPreview {
+	stone := level.selectedStone
+	preview := AimPreview{path: []rl.Vector2{stone.pos}}
+
+	remaining := travelDistance(rl.Vector2Length(velocity))
+	if remaining == 0 {
+		return preview
+	}
+
+	// the wells bend the path, it's stepped through instead
+	if len(level.levelSettings.wells) > 0 {
+		return level.simulateShot(stone, velocity, bounces)
+	}
+
+	pos := stone.pos
+	direction := rl.Vector2Normalize(velocity)
+
+	portals := 0
+	for leg := 0; leg <= bounces; {
+		contactT := remaining
+		for i := range level.stones {
+			other := &level.stones[i]
+			if other == stone || other.isDead {
+				continue
+			}
+			if t, ok := rayToCircle(pos, direction, other.pos, stone.radius+other.radius); ok && t < contactT {
+				contactT = t
+				preview.contact = other
+			}
+		}
+
+		wallT, normal := level.rayToWalls(pos, direction, stone.radius)
+		// the stone is gone once its center is over a pit or out of the field
+		lostT := level.rayToRingOut(pos, direction)
+		portalT, exitPos, exitDirection, hitsPortal := level.rayToPortal(pos, direction)
+		if !hitsPortal || portals >= maxPreviewPortals {
+			portalT = float32(math.Inf(1))
+		}
+
+		if preview.contact != nil && contactT <= min(wallT, lostT, portalT) {
+			preview.contactPos = rl.Vector2Add(pos, rl.Vector2Scale(direction, contactT))
+			preview.path = append(preview.path, preview.contactPos)
+			return preview
+		}
+		preview.contact = nil
+
+		if lostT < min(wallT, remaining, portalT) {
+			preview.path = append(preview.path, rl.Vector2Add(pos, rl.Vector2Scale(direction, lostT)))
+			return preview
+		}
+
+		if portalT < min(wallT, remaining) {
+			preview.path = append(preview.path, rl.Vector2Add(pos, rl.Vector2Scale(direction, portalT)))
+			preview.jumps = append(preview.jumps, len(preview.path))
+			preview.path = append(preview.path, exitPos)
+
+			remaining -= portalT
+			pos, direction = exitPos, exitDirection
+			portals++
+			continue
+		}
+
+		if wallT >= remaining {
+			preview.path = append(preview.path, rl.Vector2Add(pos, rl.Vector2Scale(direction, remaining)))
+			return preview
+		}
+
+		pos = rl.Vector2Add(pos, rl.Vector2Scale(direction, wallT))
+		preview.path = append(preview.path, pos)
+
+		remaining -= wallT
+		direction = reflect(direction, normal)
+		leg++
+	}
+
+	return preview
+}
+
+// drawAimPreview - the power meter around the stone and the predicted path
+func (level *Level) drawAimPreview() {
+	stone := level.selectedStone
+	if stone == nil || level.action != StoneAimed || level.playerSettings[level.playerTurn].isCpu {
+		return
+	}
+
+	strength := level.shotStrength()
+
+	// the meter goes from green to red as the pull gets to the max
+	meterColor := rl.NewColor(
+		uint8(80+175*strength),
+		uint8(220-150*strength),
+		90,
+		200,
+	)
+	rl.DrawRing(stone.pos, stone.radius*1.5, stone.radius*1.65, -90, -90+360*strength, 0, meterColor)
+	rl.DrawRing(stone.pos, stone.radius*1.5, stone.radius*1.65, -90+360*strength, 270, 0, dimWhite(30))
+
+	if level.levelSettings.noTrajectoryPreview || config.Difficulty == Hard {
+		return
+	}
+
+	bounces := 1
+	if config.Difficulty == Easy {
+		bounces = 2
+	}
+
+	preview := level.predictShot(level.shotVelocity(), bounces)
+
+	// a dotted line, so it doesn't read as the aim line
+	spacing := stone.radius * 0.6
+	for i := 1; i < len(preview.path); i++ {
+		if slices.Contains(preview.jumps, i) {
+			continue
+		}
+		from, to := preview.path[i-1], preview.path[i]
+		length := rl.Vector2Distance(from, to)
+		for d := float32(0); d < length; d += spacing {
+			rl.DrawCircleV(rl.Vector2Lerp(from, to, d/length), stone.radius*0.08, dimWhite(90))
+		}
+	}
+
+	if preview.contact != nil {
+		// the ghost of the shot stone where it touches the other one
+		rl.DrawRing(preview.contactPos, stone.radius*0.92, stone.radius, 0, 360, 0, dimWhite(140))
+		rl.DrawRing(preview.contact.pos, preview.contact.radius*1.05, preview.contact.radius*1.15, 0, 360, 0, dimWhite(90))
+	}
+}
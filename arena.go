@@ -0,0 +1,377 @@
+package main
+
+import (
+	"math"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Arena - the walls of a bordered level. the outer wall is a polygon, convex or not,
+// and the holes are obstacles inside it that the stones bounce off of, polygons or circles
+type Arena struct {
+	outline []rl.Vector2 // the corners of a polygon in order, empty for a circle
+	open    []bool       // the sides the stones leave through instead of bouncing, each starts at the corner with the same index
+	center  rl.Vector2   // circles only
+	radius  float32      // circles only
+	holes   []Arena      // their own holes are ignored
+}
+
+// WallContact - where a stone touches a wall, the normal points away from the wall into the arena
+type WallContact struct {
+	point  rl.Vector2
+	normal rl.Vector2
+	depth  float32
+}
+
+func rectangleArena(rect rl.Rectangle) Arena {
+	return polygonArena(
+		rl.NewVector2(rect.X, rect.Y),
+		rl.NewVector2(rect.X+rect.Width, rect.Y),
+		rl.NewVector2(rect.X+rect.Width, rect.Y+rect.Height),
+		rl.NewVector2(rect.X, rect.Y+rect.Height),
+	)
+}
+
+// circleArena - a round pillar, only the holes can be circles
+func circleArena(center rl.Vector2, radius float32) Arena {
+	return Arena{center: center, radius: radius}
+}
+
+func polygonArena(points ...rl.Vector2) Arena {
+	return Arena{outline: points}
+}
+
+// chamferedArena - a rectangle with its corners cut off, the sides go clockwise from the top one
+func chamferedArena(rect rl.Rectangle, cut float32) Arena {
+	left, top := rect.X, rect.Y
+	right, bottom := rect.X+rect.Width, rect.Y+rect.Height
+	return polygonArena(
+		rl.NewVector2(left+cut, top),
+		rl.NewVector2(right-cut, top),
+		rl.NewVector2(right, top+cut),
+		rl.NewVector2(right, bottom-cut),
+		rl.NewVector2(right-cut, bottom),
+		rl.NewVector2(left+cut, bottom),
+		rl.NewVector2(left, bottom-cut),
+		rl.NewVector2(left, top+cut),
+	)
+}
+
+func (arena Arena) withHoles(holes ...Arena) Arena {
+	arena.holes = append(append([]Arena{}, arena.holes...), holes...)
+	return arena
+}
+
+// withOpenSides - only the other sides are walls. for a rectangle they go top, right, bottom, left
+func (arena Arena) withOpenSides(sides ...int) Arena {
+	arena.open = make([]bool, len(arena.outline))
+	for _, side := range sides {
+		arena.open[side] = true
+	}
+	return arena
+}
+
+func (arena Arena) isOpen(side int) bool {
+	return side < len(arena.open) && arena.open[side]
+}
+
+func (arena Arena) hasOpenSides() bool {
+	return slices.Contains(arena.open, true)
+}
+
+func (arena Arena) isCircle() bool {
+	return len(arena.outline) == 0
+}
+
+func (arena Arena) edges() [][2]rl.Vector2 {
+	edges := [][2]rl.Vector2{}
+	for i, point := range arena.outline {
+		edges = append(edges, [2]rl.Vector2{point, arena.outline[(i+1)%len(arena.outline)]})
+	}
+	return edges
+}
+
+// insideShape - inside the outer wall, the holes aside
+func (arena Arena) insideShape(point rl.Vector2) bool {
+	if arena.isCircle() {
+		return rl.Vector2Distance(point, arena.center) <= arena.radius
+	}
+
+	// even-odd rule, works for the concave ones too
+	inside := false
+	for _, edge := range arena.edges() {
+		a, b := edge[0], edge[1]
+		if (a.Y > point.Y) != (b.Y > point.Y) {
+			x := a.X + (point.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if point.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+func (arena Arena) contains(point rl.Vector2) bool {
+	if !arena.insideShape(point) {
+		return false
+	}
+	for _, hole := range arena.holes {
+		if hole.insideShape(point) {
+			return false
+		}
+	}
+	return true
+}
+
+func (arena Arena) bounds() rl.Rectangle {
+	minPoint, maxPoint := arena.outline[0], arena.outline[0]
+	for _, point := range arena.outline {
+		minPoint = rl.NewVector2(min(minPoint.X, point.X), min(minPoint.Y, point.Y))
+		maxPoint = rl.NewVector2(max(maxPoint.X, point.X), max(maxPoint.Y, point.Y))
+	}
+	return rl.NewRectangle(minPoint.X, minPoint.Y, maxPoint.X-minPoint.X, maxPoint.Y-minPoint.Y)
+}
+
+// scaled - the arena shrunk or grown around the middle of its bounds, the holes move with it
+func (arena Arena) scaled(scale float32) Arena {
+	bounds := arena.bounds()
+	pivot := rl.NewVector2(bounds.X+bounds.Width/2, bounds.Y+bounds.Height/2)
+	return arena.scaledAround(pivot, scale)
+}
+
+func (arena Arena) scaledAround(pivot rl.Vector2, scale float32) Arena {
+	scalePoint := func(point rl.Vector2) rl.Vector2 {
+		return rl.Vector2Add(pivot, rl.Vector2Scale(rl.Vector2Subtract(point, pivot), scale))
+	}
+
+	result := Arena{
+		open:   arena.open,
+		center: scalePoint(arena.center),
+		radius: arena.radius * scale,
+	}
+	for _, point := range arena.outline {
+		result.outline = append(result.outline, scalePoint(point))
+	}
+	for _, hole := range arena.holes {
+		result.holes = append(result.holes, hole.scaledAround(pivot, scale))
+	}
+	return result
+}
+
+// closestOnSegment - the point of the segment closest to the given one
+func closestOnSegment(point, a, b rl.Vector2) rl.Vector2 {
+	ab := rl.Vector2Subtract(b, a)
+	lengthSquared := rl.Vector2LengthSqr(ab)
+	if lengthSquared == 0 {
+		return a
+	}
+	t := rl.Clamp(rl.Vector2DotProduct(rl.Vector2Subtract(point, a), ab)/lengthSquared, 0, 1)
+	return rl.Vector2Add(a, rl.Vector2Scale(ab, t))
+}
+
+// edgeContacts - a circle touching the edges of a polygon, from whichever side its center is on
+func edgeContacts(arena Arena, pos rl.Vector2, radius float32) []WallContact {
+	contacts := []WallContact{}
+	for i, edge := range arena.edges() {
+		if arena.isOpen(i) {
+			continue
+		}
+
+		closest := closestOnSegment(pos, edge[0], edge[1])
+		away := rl.Vector2Subtract(pos, closest)
+		distance := rl.Vector2Length(away)
+		if distance >= radius || distance == 0 {
+			continue
+		}
+		contacts = append(contacts, WallContact{
+			point:  closest,
+			normal: rl.Vector2Scale(away, 1/distance),
+			depth:  radius - distance,
+		})
+	}
+	return contacts
+}
+
+// wallContacts - every wall the stone overlaps, the stone's center is expected to be inside the arena
+func (arena Arena) wallContacts(pos rl.Vector2, radius float32) []WallContact {
+	contacts := edgeContacts(arena, pos, radius)
+
+	for _, hole := range arena.holes {
+		if !hole.isCircle() {
+			contacts = append(contacts, edgeContacts(hole, pos, radius)...)
+			continue
+		}
+
+		fromCenter := rl.Vector2Subtract(pos, hole.center)
+		distance := rl.Vector2Length(fromCenter)
+		if distance < radius+hole.radius && distance > 0 {
+			outward := rl.Vector2Scale(fromCenter, 1/distance)
+			contacts = append(contacts, WallContact{
+				point:  rl.Vector2Add(hole.center, rl.Vector2Scale(outward, hole.radius)),
+				normal: outward,
+				depth:  radius + hole.radius - distance,
+			})
+		}
+	}
+
+	return contacts
+}
+
+// distanceToWall - how far the point is from the closest wall
+func (arena Arena) distanceToWall(point rl.Vector2) float32 {
+	distance := float32(math.Inf(1))
+
+	shapes := append([]Arena{arena}, arena.holes...)
+	for _, shape := range shapes {
+		if shape.isCircle() {
+			distance = min(distance, rl.Vector2Distance(point, shape.center)-shape.radius)
+			continue
+		}
+
+		for _, edge := range shape.edges() {
+			distance = min(distance, rl.Vector2Distance(point, closestOnSegment(point, edge[0], edge[1])))
+		}
+	}
+
+	return distance
+}
+
+// rayToEdges - the first edge of the polygon a circle moving along the ray touches, and its normal
+func rayToEdges(arena Arena, origin, direction rl.Vector2, radius float32) (float32, rl.Vector2, bool) {
+	bestT := float32(math.Inf(1))
+	bestNormal := rl.NewVector2(0, 0)
+	found := false
+
+	for i, edge := range arena.edges() {
+		a, b := edge[0], edge[1]
+		along := rl.Vector2Subtract(b, a)
+		if arena.isOpen(i) || rl.Vector2LengthSqr(along) == 0 {
+			continue
+		}
+
+		// the normal facing the side the circle is on
+		normal := rl.Vector2Normalize(rl.NewVector2(-along.Y, along.X))
+		gap := rl.Vector2DotProduct(rl.Vector2Subtract(origin, a), normal)
+		if gap < 0 {
+			normal = rl.Vector2Negate(normal)
+			gap = -gap
+		}
+
+		approach := rl.Vector2DotProduct(direction, normal)
+		if approach >= 0 {
+			continue
+		}
+
+		// already touching it counts as hitting it right away
+		t := max(gap-radius, 0) / -approach
+		touch := rl.Vector2Subtract(rl.Vector2Add(origin, rl.Vector2Scale(direction, t)), rl.Vector2Scale(normal, radius))
+		u := rl.Vector2DotProduct(rl.Vector2Subtract(touch, a), along) / rl.Vector2LengthSqr(along)
+		if u < 0 || u > 1 || t >= bestT {
+			continue
+		}
+
+		bestT, bestNormal, found = t, normal, true
+	}
+
+	// the corners sticking into the arena
+	for i, corner := range arena.outline {
+		// the ends of the walls only, between two open sides there's nothing to hit
+		if arena.isOpen(i) && arena.isOpen((i+len(arena.outline)-1)%len(arena.outline)) {
+			continue
+		}
+		if rl.Vector2Distance(origin, corner) <= radius {
+			continue
+		}
+		if t, ok := rayToCircle(origin, direction, corner, radius); ok && t < bestT {
+			hit := rl.Vector2Add(origin, rl.Vector2Scale(direction, t))
+			bestT, bestNormal, found = t, rl.Vector2Normalize(rl.Vector2Subtract(hit, corner)), true
+		}
+	}
+
+	return bestT, bestNormal, found
+}
+
+// rayCast - the distance along the ray until a circle of the radius touches a wall, and the wall's normal there
+func (arena Arena) rayCast(origin, direction rl.Vector2, radius float32) (float32, rl.Vector2, bool) {
+	bestT := float32(math.Inf(1))
+	bestNormal := rl.NewVector2(0, 0)
+	found := false
+
+	if t, normal, ok := rayToEdges(arena, origin, direction, radius); ok {
+		bestT, bestNormal, found = t, normal, true
+	}
+
+	for _, hole := range arena.holes {
+		if hole.isCircle() {
+			if rl.Vector2Distance(origin, hole.center) <= hole.radius+radius {
+				continue
+			}
+			if t, ok := rayToCircle(origin, direction, hole.center, hole.radius+radius); ok && t < bestT {
+				hit := rl.Vector2Add(origin, rl.Vector2Scale(direction, t))
+				bestT, bestNormal, found = t, rl.Vector2Normalize(rl.Vector2Subtract(hit, hole.center)), true
+			}
+			continue
+		}
+
+		if t, normal, ok := rayToEdges(hole, origin, direction, radius); ok && t < bestT {
+			bestT, bestNormal, found = t, normal, true
+		}
+	}
+
+	return bestT, bestNormal, found
+}
+
+// rayToOpenSide - the distance along the ray until it leaves through one of the open sides
+func (arena Arena) rayToOpenSide(origin, direction rl.Vector2) (float32, bool) {
+	bestT := float32(math.Inf(1))
+	found := false
+
+	for i, edge := range arena.edges() {
+		if !arena.isOpen(i) {
+			continue
+		}
+
+		// solves origin + direction*t = a + along*u
+		a, along := edge[0], rl.Vector2Subtract(edge[1], edge[0])
+		denominator := direction.X*along.Y - direction.Y*along.X
+		if denominator == 0 {
+			continue
+		}
+		offset := rl.Vector2Subtract(a, origin)
+		t := (offset.X*along.Y - offset.Y*along.X) / denominator
+		u := (offset.X*direction.Y - offset.Y*direction.X) / denominator
+		if t >= 0 && u >= 0 && u <= 1 && t < bestT {
+			bestT, found = t, true
+		}
+	}
+
+	return bestT, found
+}
+
+// reflect - the direction bounced off a wall with the given normal
+func reflect(direction, normal rl.Vector2) rl.Vector2 {
+	return rl.Vector2Subtract(direction, rl.Vector2Scale(normal, 2*rl.Vector2DotProduct(direction, normal)))
+}
+
+func (arena Arena) draw(thickness float32, color rl.Color) {
+	shapes := append([]Arena{arena}, arena.holes...)
+	for _, shape := range shapes {
+		if shape.isCircle() {
+			rl.DrawCircleV(shape.center, shape.radius, rl.ColorAlpha(color, 0.25))
+			rl.DrawRing(shape.center, shape.radius-thickness, shape.radius, 0, 360, 0, color)
+			continue
+		}
+
+		for side, edge := range shape.edges() {
+			if shape.isOpen(side) {
+				drawDashedLine(edge[0], edge[1], thickness/2, rl.ColorAlpha(color, 0.5))
+				continue
+			}
+			rl.DrawLineEx(edge[0], edge[1], thickness, color)
+			// rounds the joints
+			rl.DrawCircleV(edge[0], thickness/2, color)
+			rl.DrawCircleV(edge[1], thickness/2, color)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// the time left is shown with tenths of a second below this
+const clockFinalStretch = 10 * time.Second
+
+// MatchClock - how long a match has been going on, and how much of it is left in timed levels.
+// it only moves while the match isn't paused
+type MatchClock struct {
+	elapsed time.Duration
+	limit   time.Duration // no limit when 0
+	paused  bool
+}
+
+func newMatchClock(limit time.Duration) MatchClock {
+	return MatchClock{limit: limit}
+}
+
+func (clock *MatchClock) tick(dt float32) {
+	if clock.paused {
+		return
+	}
+	clock.elapsed += time.Duration(float64(dt) * float64(time.Second))
+}
+
+func (clock *MatchClock) pause() {
+	clock.paused = true
+}
+
+func (clock *MatchClock) resume() {
+	clock.paused = false
+}
+
+// seconds - the elapsed time, for the match log and the animations
+func (clock *MatchClock) seconds() float32 {
+	return float32(clock.elapsed.Seconds())
+}
+
+func (clock *MatchClock) remaining() time.Duration {
+	return max(clock.limit-clock.elapsed, 0)
+}
+
+func (clock *MatchClock) isUp() bool {
+	return clock.limit > 0 && clock.elapsed >= clock.limit
+}
+
+// display - the time left as "1:05", "42" or, in the final stretch, "7.3"
+func (clock *MatchClock) display() string {
+	remaining := clock.remaining()
+
+	// rounded up like the whole seconds below, so "0.0" isn't shown while there's still time
+	tenths := (remaining + 100*time.Millisecond - 1).Truncate(100 * time.Millisecond)
+	if remaining > 0 && tenths < clockFinalStretch {
+		return fmt.Sprintf("%.1f", tenths.Seconds())
+	}
+
+	// whole seconds are rounded up, so it only shows 0 when the time is up
+	seconds := int(math.Ceil(remaining.Seconds()))
+	if seconds >= 60 {
+		return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+	}
+	return fmt.Sprintf("%02d", seconds)
+}
+
+// displayTemplate - the display with every digit as 0, the timer box is sized to it
+// so it doesn't change width with every tick of the non-monospaced font
+func (clock *MatchClock) displayTemplate() string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return '0'
+		}
+		return r
+	}, clock.display())
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchClock(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     time.Duration
+		elapsed   time.Duration
+		display   string
+		remaining time.Duration
+		isUp      bool
+	}{
+		{"no limit", 0, 30 * time.Second, "00", 0, false},
+		{"over four minutes", 5 * time.Minute, 30 * time.Second, "4:30", 270 * time.Second, false},
+		{"whole seconds round up", 45 * time.Second, 2500 * time.Millisecond, "43", 42500 * time.Millisecond, false},
+		{"right before the final stretch", 45 * time.Second, 35050 * time.Millisecond, "10", 9950 * time.Millisecond, false},
+		{"final stretch", 45 * time.Second, 37750 * time.Millisecond, "7.3", 7250 * time.Millisecond, false},
+		{"last second", 45 * time.Second, 44250 * time.Millisecond, "0.8", 750 * time.Millisecond, false},
+		{"last tenth", 45 * time.Second, 44950 * time.Millisecond, "0.1", 50 * time.Millisecond, false},
+		{"time is up", 45 * time.Second, 45 * time.Second, "00", 0, true},
+		{"past the limit", 45 * time.Second, 47 * time.Second, "00", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := newMatchClock(test.limit)
+			clock.elapsed = test.elapsed
+
+			if got := clock.display(); got != test.display {
+				t.Errorf("display() = %q, want %q", got, test.display)
+			}
+			if got := clock.remaining(); got != test.remaining {
+				t.Errorf("remaining() = %v, want %v", got, test.remaining)
+			}
+			if got := clock.isUp(); got != test.isUp {
+				t.Errorf("isUp() = %v, want %v", got, test.isUp)
+			}
+		})
+	}
+}
+
+func TestMatchClockPause(t *testing.T) {
+	clock := newMatchClock(time.Minute)
+	clock.tick(1)
+	clock.pause()
+	clock.tick(1)
+	clock.resume()
+	clock.tick(0.5)
+
+	if got := clock.elapsed; got != 1500*time.Millisecond {
+		t.Errorf("elapsed = %v, want 1.5s", got)
+	}
+}
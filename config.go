@@ -0,0 +1,51 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const configFileName = "config.json"
+
+// GameConfig - the player's preferences that outlive the session
+type GameConfig struct {
+	HotSeat       bool                  `json:"hotSeat"` // the second player is a person sharing the screen instead of the cpu
+	Difficulty    Difficulty            `json:"difficulty"`
+	Accessibility AccessibilitySettings `json:"accessibility"`
+}
+
+func defaultConfig() GameConfig {
+	return GameConfig{
+		Difficulty:    Normal,
+		Accessibility: defaultAccessibilitySettings(),
+	}
+}
+
+var config = defaultConfig()
+
+func (c *GameConfig) load() {
+	loaded := defaultConfig()
+	if err := loadJSON(configFileName, &loaded); err != nil {
+		rl.TraceLog(rl.LogInfo, "config could not be loaded: %v", err)
+		return
+	}
+	loaded.Difficulty = min(loaded.Difficulty, TotalDifficulties-1)
+	loaded.Accessibility.TextScale = rl.Clamp(loaded.Accessibility.TextScale, minTextScale, maxTextScale)
+	*c = loaded
+}
+
+func (c *GameConfig) save() {
+	if err := saveJSON(configFileName, c); err != nil {
+		rl.TraceLog(rl.LogWarning, "config could not be saved: %v", err)
+	}
+}
+
+// applyToPlayers - the levels are written for "you vs cpu", the config decides who's actually playing
+func (c *GameConfig) applyToPlayers(playerSettings [TotalPlayerCount]PlayerSettings) [TotalPlayerCount]PlayerSettings {
+	if c.HotSeat {
+		playerSettings[PlayerOne].label = "p1"
+		playerSettings[PlayerTwo].label = "p2"
+		playerSettings[PlayerOne].isCpu = false
+		playerSettings[PlayerTwo].isCpu = false
+	}
+	return playerSettings
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	gui "github.com/gen2brain/raylib-go/raygui"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const notListening = -1
+
+type SceneControls struct {
+	nextSceneId SceneId
+	returnData  any
+	listening   int // the action waiting for a key press, notListening otherwise
+
+	resetClicked bool
+	backClicked  bool
+}
+
+func NewSceneControls() SceneControls {
+	return SceneControls{
+		listening: notListening,
+	}
+}
+
+func (scene *SceneControls) GetId() SceneId {
+	return Controls
+}
+
+func (scene *SceneControls) Init(data any, window *Window) {
+	scene.nextSceneId = scene.GetId()
+	// whatever the options scene was carrying is handed back to it
+	scene.returnData = data
+	scene.listening = notListening
+	scene.resetClicked = false
+	scene.backClicked = false
+}
+
+func (scene *SceneControls) HandleUserInput(window *Window) {
+	if scene.listening == notListening {
+		return
+	}
+
+	key := rl.GetKeyPressed()
+	switch key {
+	case rl.KeyNull:
+	case rl.KeyEscape:
+		// backs out of the rebind, the action keeps its keys
+		scene.listening = notListening
+	default:
+		inputBindings.bind(InputAction(scene.listening), key)
+		inputBindings.save()
+		scene.listening = notListening
+	}
+}
+
+func (scene *SceneControls) Update(window *Window) (SceneId, any) {
+	if scene.resetClicked {
+		inputBindings = defaultInputBindings()
+		inputBindings.save()
+		scene.listening = notListening
+	}
+
+	if scene.backClicked {
+		return Options, scene.returnData
+	}
+
+	return scene.nextSceneId, nil
+}
+
+func (scene *SceneControls) Draw(window *Window) {
+	rl.ClearBackground(BG_COLOR)
+
+	ScreenWidth, ScreenHeight := window.GetScreenDimensions()
+
+	drawGuiTitle(window, "CONTROLS")
+
+	rowHeight := ScreenHeight / 20
+	yAxis := ScreenHeight * 0.3
+
+	for action, info := range InputActionList {
+		gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
+		gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
+		gui.Label(rl.NewRectangle(0, yAxis, ScreenWidth*0.45, rowHeight), info.label)
+
+		text := inputBindings.describe(InputAction(action))
+		if scene.listening == action {
+			text = "press a key, esc to cancel"
+		}
+
+		if gui.Button(rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, rowHeight), text) {
+			if scene.listening == action {
+				scene.listening = notListening
+			} else {
+				scene.listening = action
+			}
+		}
+
+		yAxis += rowHeight
+	}
+
+	yAxis += rowHeight * 0.5
+
+	scene.resetClicked = gui.Button(
+		rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, rowHeight),
+		"reset to defaults",
+	)
+
+	yAxis += rowHeight
+
+	scene.backClicked = gui.Button(
+		rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, rowHeight),
+		"back",
+	)
+}
+
+func (scene *SceneControls) Teardown(window *Window) {
+
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+
+	gui "github.com/gen2brain/raylib-go/raygui"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type CustomizeRow = int
+
+const (
+	ProfileRow         CustomizeRow = iota
+	PaletteRow         CustomizeRow = iota
+	RingRow            CustomizeRow = iota
+	EmblemRow          CustomizeRow = iota
+	CustomizeBackRow   CustomizeRow = iota
+	TotalCustomizeRows CustomizeRow = iota
+)
+
+type SceneCustomize struct {
+	nextSceneId SceneId
+	returnData  any
+	profile     Player // whose style is being edited
+
+	focusRow     CustomizeRow
+	focusVisible bool
+
+	backClicked bool
+}
+
+func NewSceneCustomize() SceneCustomize {
+	return SceneCustomize{}
+}
+
+func (scene *SceneCustomize) GetId() SceneId {
+	return Customize
+}
+
+func (scene *SceneCustomize) Init(data any, window *Window) {
+	scene.nextSceneId = scene.GetId()
+	// whatever the options scene was carrying is handed back to it
+	scene.returnData = data
+	scene.profile = PlayerOne
+	scene.focusVisible = false
+	scene.backClicked = false
+}
+
+func (scene *SceneCustomize) HandleUserInput(window *Window) {
+	if menuBackPressed() {
+		scene.backClicked = true
+		return
+	}
+
+	step := 0
+	if menuUpPressed() {
+		step = -1
+	}
+	if menuDownPressed() {
+		step = 1
+	}
+
+	if step != 0 {
+		if scene.focusVisible {
+			scene.focusRow = (scene.focusRow + step + TotalCustomizeRows) % TotalCustomizeRows
+		} else {
+			scene.focusRow = 0
+		}
+		scene.focusVisible = true
+	}
+
+	if rl.Vector2Length(rl.GetMouseDelta()) > 0 {
+		scene.focusVisible = false
+	}
+
+	if !scene.focusVisible {
+		return
+	}
+
+	if menuLeftPressed() {
+		scene.cycle(scene.focusRow, -1)
+	}
+
+	if menuRightPressed() {
+		scene.cycle(scene.focusRow, 1)
+	}
+
+	if menuConfirmPressed() {
+		if scene.focusRow == CustomizeBackRow {
+			scene.backClicked = true
+		} else {
+			scene.cycle(scene.focusRow, 1)
+		}
+	}
+}
+
+// cycle - steps through the entries of a row, locked ones included so they can be previewed
+func (scene *SceneCustomize) cycle(row CustomizeRow, direction int) {
+	wrap := func(ix, count int) int {
+		return (ix + direction + count) % count
+	}
+
+	style := &stoneProfiles.styles[scene.profile]
+
+	switch row {
+	case ProfileRow:
+		scene.profile = Player(wrap(int(scene.profile), int(TotalPlayerCount)))
+		return
+	case PaletteRow:
+		style.palette = wrap(style.palette, len(PaletteList))
+	case RingRow:
+		style.ring = RingStyle(wrap(int(style.ring), int(TotalRingStyle)))
+	case EmblemRow:
+		style.emblem = Emblem(wrap(int(style.emblem), int(TotalEmblems)))
+	default:
+		return
+	}
+
+	// a locked pick is kept, it's worn as soon as it's unlocked
+	stoneProfiles.save()
+}
+
+func (scene *SceneCustomize) Update(window *Window) (SceneId, any) {
+	if scene.backClicked {
+		return Options, scene.returnData
+	}
+
+	return scene.nextSceneId, nil
+}
+
+func (scene *SceneCustomize) focusState(row CustomizeRow) {
+	if scene.focusVisible && scene.focusRow == row {
+		gui.SetState(gui.STATE_FOCUSED)
+	} else {
+		gui.SetState(gui.STATE_NORMAL)
+	}
+}
+
+func (scene *SceneCustomize) Draw(window *Window) {
+	rl.ClearBackground(BG_COLOR)
+
+	ScreenWidth, ScreenHeight := window.GetScreenDimensions()
+
+	drawGuiTitle(window, "STONES")
+
+	style := stoneProfiles.styles[scene.profile]
+
+	rows := []struct {
+		row    CustomizeRow
+		label  string
+		option StyleOption
+	}{
+		{ProfileRow, "profile", StyleOption{label: fmt.Sprintf("player %d", scene.profile+1), unlockedBy: NoAchievement}},
+		{PaletteRow, "colors", PaletteList[style.palette].StyleOption},
+		{RingRow, "ring", RingStyleList[style.ring]},
+		{EmblemRow, "emblem", EmblemList[style.emblem]},
+	}
+
+	rowHeight := ScreenHeight / 20
+	yAxis := ScreenHeight / 3
+	locked := []StyleOption{}
+
+	for _, row := range rows {
+		gui.SetState(gui.STATE_NORMAL)
+		gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
+		gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
+		gui.Label(rl.NewRectangle(0, yAxis, ScreenWidth*0.3, rowHeight), row.label)
+
+		text := row.option.label
+		if !row.option.isUnlocked() {
+			text += " (locked)"
+			locked = append(locked, row.option)
+		}
+
+		scene.focusState(row.row)
+		if gui.Button(rl.NewRectangle(ScreenWidth*0.32, yAxis, rowHeight, rowHeight), "<") {
+			scene.cycle(row.row, -1)
+		}
+		if gui.Button(rl.NewRectangle(ScreenWidth*0.32+rowHeight, yAxis, ScreenWidth*0.2, rowHeight), text) {
+			scene.cycle(row.row, 1)
+		}
+		if gui.Button(rl.NewRectangle(ScreenWidth*0.52+rowHeight, yAxis, rowHeight, rowHeight), ">") {
+			scene.cycle(row.row, 1)
+		}
+
+		yAxis += rowHeight
+	}
+
+	// how to get the locked ones
+	gui.SetState(gui.STATE_NORMAL)
+	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_LEFT))
+	for _, option := range locked {
+		achievement := AchievementList[option.unlockedBy]
+		gui.Label(
+			rl.NewRectangle(ScreenWidth*0.32, yAxis, ScreenWidth*0.4, rowHeight),
+			fmt.Sprintf("%s: %s", option.label, achievement.description),
+		)
+		yAxis += rowHeight
+	}
+
+	// the live preview, locked picks included so they can be tried on
+	palette := PaletteList[style.palette].palette
+	settings := getPlayer("", palette, false)
+	settings.ringStyle = style.ring
+	settings.emblem = style.emblem
+
+	center := rl.NewVector2(ScreenWidth*0.75, ScreenHeight*0.45)
+	radius := StoneRadius * 2.5
+	life := float32(100 - int(rl.GetTime()*20)%100)
+	drawStoneFace(center, radius, life, settings)
+
+	scene.focusState(CustomizeBackRow)
+	scene.backClicked = scene.backClicked || gui.Button(
+		rl.NewRectangle(ScreenWidth*0.32+rowHeight, ScreenHeight*0.8, ScreenWidth*0.2, rowHeight),
+		"back",
+	)
+
+	gui.SetState(gui.STATE_NORMAL)
+}
+
+func (scene *SceneCustomize) Teardown(window *Window) {
+
+}
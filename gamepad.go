@@ -0,0 +1,164 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const gamepadStickDeadzone = 0.2
+
+// the pads use fixed buttons, only the keyboard is rebindable
+var gamepadButtons = [TotalInputActions][]int32{
+	SelectNextStone:     {rl.GamepadButtonRightTrigger1, rl.GamepadButtonLeftFaceRight},
+	SelectPreviousStone: {rl.GamepadButtonLeftTrigger1, rl.GamepadButtonLeftFaceLeft},
+	IncreasePower:       {rl.GamepadButtonLeftFaceUp},
+	DecreasePower:       {rl.GamepadButtonLeftFaceDown},
+	FineAim:             {rl.GamepadButtonRightFaceLeft},
+	Fire:                {rl.GamepadButtonRightTrigger2, rl.GamepadButtonRightFaceDown},
+	Cancel:              {rl.GamepadButtonLeftTrigger2, rl.GamepadButtonRightFaceRight},
+	PauseGame:           {rl.GamepadButtonMiddleRight},
+}
+
+// gamepadOf - in a hot-seat match with two pads, each player has their own.
+// otherwise whoever is playing uses the first pad
+func gamepadOf(player Player) int32 {
+	if config.HotSeat && player == PlayerTwo && rl.IsGamepadAvailable(1) {
+		return 1
+	}
+	return 0
+}
+
+func isPadPressed(action InputAction, gamepad int32) bool {
+	if !rl.IsGamepadAvailable(gamepad) {
+		return false
+	}
+	for _, button := range gamepadButtons[action] {
+		if rl.IsGamepadButtonPressed(gamepad, button) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPadDown(action InputAction, gamepad int32) bool {
+	if !rl.IsGamepadAvailable(gamepad) {
+		return false
+	}
+	for _, button := range gamepadButtons[action] {
+		if rl.IsGamepadButtonDown(gamepad, button) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAnyPadPressed(button int32) bool {
+	for gamepad := int32(0); gamepad < 2; gamepad++ {
+		if rl.IsGamepadAvailable(gamepad) && rl.IsGamepadButtonPressed(gamepad, button) {
+			return true
+		}
+	}
+	return false
+}
+
+// gamepadStick - the left stick with the deadzone cut out and rescaled, so the length goes 0..1
+func gamepadStick(gamepad int32) rl.Vector2 {
+	if !rl.IsGamepadAvailable(gamepad) {
+		return rl.NewVector2(0, 0)
+	}
+
+	stick := rl.NewVector2(
+		rl.GetGamepadAxisMovement(gamepad, rl.GamepadAxisLeftX),
+		rl.GetGamepadAxisMovement(gamepad, rl.GamepadAxisLeftY),
+	)
+
+	length := rl.Vector2Length(stick)
+	if length < gamepadStickDeadzone {
+		return rl.NewVector2(0, 0)
+	}
+
+	scaled := rl.Clamp((length-gamepadStickDeadzone)/(1-gamepadStickDeadzone), 0, 1)
+	return rl.Vector2Scale(stick, scaled/length)
+}
+
+// menu navigation works with the d-pad of any pad and the arrow keys
+
+func menuUpPressed() bool {
+	return rl.IsKeyPressed(rl.KeyUp) || isAnyPadPressed(rl.GamepadButtonLeftFaceUp)
+}
+
+func menuDownPressed() bool {
+	return rl.IsKeyPressed(rl.KeyDown) || isAnyPadPressed(rl.GamepadButtonLeftFaceDown)
+}
+
+func menuLeftPressed() bool {
+	return rl.IsKeyPressed(rl.KeyLeft) || isAnyPadPressed(rl.GamepadButtonLeftFaceLeft)
+}
+
+func menuRightPressed() bool {
+	return rl.IsKeyPressed(rl.KeyRight) || isAnyPadPressed(rl.GamepadButtonLeftFaceRight)
+}
+
+func menuConfirmPressed() bool {
+	return rl.IsKeyPressed(rl.KeyEnter) || isAnyPadPressed(rl.GamepadButtonRightFaceDown)
+}
+
+func menuBackPressed() bool {
+	return isAnyPadPressed(rl.GamepadButtonRightFaceRight)
+}
+
+// menuFocus - keyboard and gamepad navigation over the interactable buttons of a menu.
+// the focus shows up once the d-pad or the arrows are used and hides again when the mouse moves
+type menuFocus struct {
+	index   int
+	visible bool
+}
+
+// update - sets the active flag of the buttons, either from the mouse or from the focus
+func (focus *menuFocus) update(buttons []buttonRectangle) {
+	selectable := []int{}
+	for i, button := range buttons {
+		if button.interactable {
+			selectable = append(selectable, i)
+		}
+	}
+
+	if len(selectable) == 0 {
+		return
+	}
+
+	step := 0
+	if menuUpPressed() {
+		step = -1
+	}
+	if menuDownPressed() {
+		step = 1
+	}
+
+	if step != 0 {
+		if focus.visible {
+			focus.index = (focus.index + step + len(selectable)) % len(selectable)
+		} else {
+			focus.index = 0
+		}
+		focus.visible = true
+	}
+
+	if rl.Vector2Length(rl.GetMouseDelta()) > 0 {
+		focus.visible = false
+	}
+
+	focus.index = min(focus.index, len(selectable)-1)
+
+	for i := range buttons {
+		buttons[i].active = buttons[i].interactable && !focus.visible && touchInput.isOver(buttons[i].rectangle)
+	}
+
+	if focus.visible {
+		buttons[selectable[focus.index]].active = true
+	}
+}
+
+// activated - whether the active button was clicked, tapped or confirmed this frame
+func (focus *menuFocus) activated() bool {
+	return rl.IsMouseButtonReleased(rl.MouseButtonLeft) || touchInput.tapped() || (focus.visible && menuConfirmPressed())
+}
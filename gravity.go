@@ -0,0 +1,147 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	maxSimulationSteps  = 900     // frames, the slowest shots have stopped long before
+	simulationStepTime  = 1. / 60 // the frame time the shots are simulated with
+	simulationPathEvery = 4       // frames between the points of the simulated path
+)
+
+// GravityWell - pulls the moving stones towards its center, or pushes them away when the strength is negative.
+// the force fades out linearly and is gone at the edge of its radius
+type GravityWell struct {
+	pos      rl.Vector2
+	strength float32 // of MaxPushVelocityAllowed, added to the velocity every frame at the center
+	radius   float32
+}
+
+func (well GravityWell) isRepulsor() bool {
+	return well.strength < 0
+}
+
+// applyGravity - the wells bend the path of the moving stones.
+// the ones at rest stay put, so the turns still end with the stones at rest
+func (level *Level) applyGravity(s *Stone) {
+	if rl.Vector2Length(s.velocity) == 0 {
+		return
+	}
+
+	for _, well := range level.levelSettings.wells {
+		toCenter := rl.Vector2Subtract(well.pos, s.pos)
+		distance := rl.Vector2Length(toCenter)
+		if distance >= well.radius || distance == 0 {
+			continue
+		}
+
+		falloff := 1 - distance/well.radius
+		pull := rl.Vector2Scale(toCenter, well.strength*MaxPushVelocityAllowed*falloff/distance)
+		s.velocity = rl.Vector2Add(s.velocity, pull)
+	}
+}
+
+// simulateShot - steps the shot frame by frame the way update moves the stones, for the fields
+// the straight lines of predictShot can't follow. the other stones are treated as still
+func (level *Level) simulateShot(stone *Stone, velocity rl.Vector2, bounces int) AimPreview {
+	preview := AimPreview{path: []rl.Vector2{stone.pos}}
+
+	shot := *stone
+	shot.velocity = velocity
+	shot.portalCooldown = 0
+
+	for step := 1; step <= maxSimulationSteps; step++ {
+		if bounced := level.simulateWalls(&shot); bounced {
+			preview.path = append(preview.path, shot.pos)
+			if bounces == 0 {
+				return preview
+			}
+			bounces--
+		}
+
+		for i := range level.stones {
+			other := &level.stones[i]
+			if other == stone || other.isDead {
+				continue
+			}
+			if rl.CheckCollisionCircles(shot.pos, shot.radius, other.pos, other.radius) {
+				preview.contact = other
+				preview.contactPos = shot.pos
+				preview.path = append(preview.path, shot.pos)
+				return preview
+			}
+		}
+
+		level.applyGravity(&shot)
+		shot.pos = rl.Vector2Add(shot.pos, shot.velocity)
+		level.applySurface(&shot)
+
+		if pair, entry, ok := level.throughPortals(&shot, simulationStepTime); ok {
+			preview.path = append(preview.path, pair.ends[entry].pos)
+			preview.jumps = append(preview.jumps, len(preview.path))
+			preview.path = append(preview.path, shot.pos)
+			continue
+		}
+
+		// the stone is gone once its center is over a pit or out of the field
+		if !rl.CheckCollisionPointRec(shot.pos, rl.NewRectangle(0, 0, CanvasWidth, CanvasHeight)) || level.caughtOutside(&shot) || level.fellInPit(&shot) {
+			preview.path = append(preview.path, shot.pos)
+			return preview
+		}
+
+		if rl.Vector2Length(shot.velocity) == 0 || step%simulationPathEvery == 0 {
+			preview.path = append(preview.path, shot.pos)
+		}
+		if rl.Vector2Length(shot.velocity) == 0 {
+			return preview
+		}
+	}
+
+	return preview
+}
+
+// simulateWalls - the walls and gates the simulated shot bounces off of
+func (level *Level) simulateWalls(shot *Stone) bool {
+	if level.caughtOutside(shot) {
+		return false
+	}
+	contacts := level.wallContacts(shot)
+	if len(contacts) == 0 {
+		return false
+	}
+	_, bounced := bounceOffWalls(shot, contacts)
+	return bounced
+}
+
+// drawWells - rings drifting in towards the attractors and out of the repulsors
+func (level *Level) drawWells() {
+	time := float64(level.clock.seconds())
+	if config.Accessibility.ReduceMotion {
+		time = 0
+	}
+
+	for _, well := range level.levelSettings.wells {
+		color := rl.NewColor(150, 110, 240, 255)
+		if well.isRepulsor() {
+			color = rl.NewColor(240, 140, 70, 255)
+		}
+
+		rl.DrawCircleV(well.pos, well.radius, rl.ColorAlpha(color, 0.06))
+		rl.DrawRing(well.pos, well.radius*0.98, well.radius, 0, 360, 0, rl.ColorAlpha(color, 0.3))
+
+		const rings = 4
+		for i := range rings {
+			phase := float32(math.Mod(time*0.4+float64(i)/rings, 1))
+			if !well.isRepulsor() {
+				phase = 1 - phase
+			}
+			r := well.radius * phase
+			rl.DrawRing(well.pos, r*0.97, r, 0, 360, 0, rl.ColorAlpha(color, 0.35*(1-phase)))
+		}
+
+		rl.DrawCircleV(well.pos, StoneRadius*0.15, color)
+	}
+}
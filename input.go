@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const controlsFileName = "controls.json"
+
+type InputAction = uint8
+
+const (
+	SelectNextStone     InputAction = iota
+	SelectPreviousStone InputAction = iota
+	RotateAimLeft       InputAction = iota
+	RotateAimRight      InputAction = iota
+	IncreasePower       InputAction = iota
+	DecreasePower       InputAction = iota
+	FineAim             InputAction = iota
+	Fire                InputAction = iota
+	Cancel              InputAction = iota
+	PauseGame           InputAction = iota
+	TotalInputActions   InputAction = iota
+)
+
+type InputActionInfo struct {
+	key   string // stable name used in the config file
+	label string
+}
+
+var InputActionList = [TotalInputActions]InputActionInfo{
+	SelectNextStone:     {"select_next", "next stone"},
+	SelectPreviousStone: {"select_previous", "previous stone"},
+	RotateAimLeft:       {"rotate_left", "rotate aim left"},
+	RotateAimRight:      {"rotate_right", "rotate aim right"},
+	IncreasePower:       {"power_up", "more power"},
+	DecreasePower:       {"power_down", "less power"},
+	FineAim:             {"fine_aim", "fine aim (hold)"},
+	Fire:                {"fire", "fire"},
+	Cancel:              {"cancel", "cancel"},
+	PauseGame:           {"pause", "pause"},
+}
+
+// InputBindings - the keys of each action, any of them triggers the action
+type InputBindings struct {
+	keys [TotalInputActions][]int32
+}
+
+type controlsFile struct {
+	Bindings map[string][]int32 `json:"bindings"`
+}
+
+func defaultInputBindings() InputBindings {
+	return InputBindings{
+		keys: [TotalInputActions][]int32{
+			SelectNextStone:     {rl.KeyTab, rl.KeyE},
+			SelectPreviousStone: {rl.KeyQ},
+			RotateAimLeft:       {rl.KeyLeft, rl.KeyA},
+			RotateAimRight:      {rl.KeyRight, rl.KeyD},
+			IncreasePower:       {rl.KeyUp, rl.KeyW},
+			DecreasePower:       {rl.KeyDown, rl.KeyS},
+			FineAim:             {rl.KeyLeftShift, rl.KeyRightShift},
+			Fire:                {rl.KeySpace, rl.KeyEnter},
+			Cancel:              {rl.KeyBackspace, rl.KeyX},
+			PauseGame:           {rl.KeyEscape, rl.KeyP},
+		},
+	}
+}
+
+var inputBindings = defaultInputBindings()
+
+func (bindings *InputBindings) load() {
+	file := controlsFile{}
+	if err := loadJSON(controlsFileName, &file); err != nil {
+		rl.TraceLog(rl.LogInfo, "controls could not be loaded: %v", err)
+		return
+	}
+
+	// the actions missing from the file keep their default keys
+	for action, info := range InputActionList {
+		if keys, ok := file.Bindings[info.key]; ok && len(keys) > 0 {
+			bindings.keys[action] = keys
+		}
+	}
+}
+
+func (bindings *InputBindings) save() {
+	file := controlsFile{Bindings: map[string][]int32{}}
+	for action, info := range InputActionList {
+		file.Bindings[info.key] = bindings.keys[action]
+	}
+
+	if err := saveJSON(controlsFileName, file); err != nil {
+		rl.TraceLog(rl.LogWarning, "controls could not be saved: %v", err)
+	}
+}
+
+// bind - the key becomes the primary key of the action. an action the key is taken from
+// gets the old primary key of this one in its place, so the two swap and neither is left without a key
+func (bindings *InputBindings) bind(action InputAction, key int32) {
+	previous := slices.DeleteFunc(slices.Clone(bindings.keys[action]), func(k int32) bool { return k == key })
+
+	for other := range TotalInputActions {
+		i := slices.Index(bindings.keys[other], key)
+		if other == action || i < 0 {
+			continue
+		}
+
+		if len(previous) > 0 && !slices.Contains(bindings.keys[other], previous[0]) {
+			bindings.keys[other][i] = previous[0]
+			previous = previous[1:]
+		} else {
+			bindings.keys[other] = slices.Delete(bindings.keys[other], i, i+1)
+		}
+	}
+
+	bindings.keys[action] = append([]int32{key}, previous...)
+	// primary + one alternative is plenty
+	if len(bindings.keys[action]) > 2 {
+		bindings.keys[action] = bindings.keys[action][:2]
+	}
+}
+
+func (bindings *InputBindings) isPressed(action InputAction) bool {
+	for _, key := range bindings.keys[action] {
+		if rl.IsKeyPressed(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (bindings *InputBindings) isDown(action InputAction) bool {
+	for _, key := range bindings.keys[action] {
+		if rl.IsKeyDown(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPressedBy - the keys or the buttons of the given gamepad
+func (bindings *InputBindings) isPressedBy(action InputAction, gamepad int32) bool {
+	return bindings.isPressed(action) || isPadPressed(action, gamepad)
+}
+
+func (bindings *InputBindings) isDownBy(action InputAction, gamepad int32) bool {
+	return bindings.isDown(action) || isPadDown(action, gamepad)
+}
+
+// isPressedByAnyone - the keys or the buttons of any of the gamepads
+func (bindings *InputBindings) isPressedByAnyone(action InputAction) bool {
+	return bindings.isPressed(action) || isPadPressed(action, 0) || isPadPressed(action, 1)
+}
+
+func (bindings *InputBindings) describe(action InputAction) string {
+	keys := bindings.keys[action]
+	switch len(keys) {
+	case 0:
+		return "-"
+	case 1:
+		return keyName(keys[0])
+	default:
+		return fmt.Sprintf("%s / %s", keyName(keys[0]), keyName(keys[1]))
+	}
+}
+
+// keyName - raylib has no key names, so here are the ones people are likely to bind
+func keyName(key int32) string {
+	switch {
+	case key >= rl.KeyA && key <= rl.KeyZ:
+		return string(rune('a' + key - rl.KeyA))
+	case key >= rl.KeyZero && key <= rl.KeyNine:
+		return string(rune('0' + key - rl.KeyZero))
+	case key >= rl.KeyF1 && key <= rl.KeyF12:
+		return fmt.Sprintf("f%d", key-rl.KeyF1+1)
+	}
+
+	switch key {
+	case rl.KeySpace:
+		return "space"
+	case rl.KeyEnter:
+		return "enter"
+	case rl.KeyEscape:
+		return "esc"
+	case rl.KeyTab:
+		return "tab"
+	case rl.KeyBackspace:
+		return "backspace"
+	case rl.KeyLeft:
+		return "left"
+	case rl.KeyRight:
+		return "right"
+	case rl.KeyUp:
+		return "up"
+	case rl.KeyDown:
+		return "down"
+	case rl.KeyLeftShift:
+		return "l-shift"
+	case rl.KeyRightShift:
+		return "r-shift"
+	case rl.KeyLeftControl:
+		return "l-ctrl"
+	case rl.KeyRightControl:
+		return "r-ctrl"
+	case rl.KeyLeftAlt:
+		return "l-alt"
+	case rl.KeyRightAlt:
+		return "r-alt"
+	case rl.KeyComma:
+		return ","
+	case rl.KeyPeriod:
+		return "."
+	case rl.KeySlash:
+		return "/"
+	case rl.KeySemicolon:
+		return ";"
+	case rl.KeyMinus:
+		return "-"
+	case rl.KeyEqual:
+		return "="
+	}
+
+	return fmt.Sprintf("key %d", key)
+}
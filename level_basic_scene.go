@@ -12,6 +12,7 @@ func NewSceneLevelsBasic() SceneLevelsBasic {
 			sceneId:         LevelBasic,
 			stonesPerPlayer: 6,
 			backgroundColor: BG_COLOR,
+			rules:           defaultRules(),
 		},
 		playerSettings: [TotalPlayerCount]PlayerSettings{
 			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
@@ -22,33 +23,23 @@ func NewSceneLevelsBasic() SceneLevelsBasic {
 
 func (scene *SceneLevelsBasic) Init(data any, window *Window) {
 	// init
-	level := newLevel(
-		scene.levelSettings,
-		scene.playerSettings,
-	)
-	level.init(window)
-	scene.level = level
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
 }
 
 func (scene *SceneLevelsBasic) GetId() SceneId {
 	return LevelBasic
 }
 
+func (scene *SceneLevelsBasic) GetLevel() *Level {
+	return &scene.level
+}
+
 func (scene *SceneLevelsBasic) HandleUserInput(window *Window) {
 	scene.level.handleUserInput(window)
 }
 
 func (scene *SceneLevelsBasic) Update(window *Window) (SceneId, any) {
-	nextSceneId := scene.GetId()
-	var levelData any = nil
-	if scene.level.status != Stopped {
-		scene.level.update(window)
-		if scene.level.status == Finished { // TODO: this needs to be elaborate - is it a win, is it a loss?
-			nextSceneId = Transition
-			levelData = &scene.level
-		}
-	}
-	return nextSceneId, levelData
+	return scene.level.updateScene(window)
 }
 
 func (scene *SceneLevelsBasic) Draw(window *Window) {
@@ -13,7 +13,8 @@ func NewSceneLevelsBordered(window *Window) SceneLevelsBordered {
 			stonesPerPlayer: 4,
 			backgroundColor: BG_COLOR,
 			isBordered:      true,
-			boundary:        window.GetScreenBoundary(),
+			arena:           rectangleArena(window.GetScreenBoundary()),
+			rules:           borderedRules(),
 		},
 		playerSettings: [TotalPlayerCount]PlayerSettings{
 			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
@@ -24,30 +25,23 @@ func NewSceneLevelsBordered(window *Window) SceneLevelsBordered {
 
 func (scene *SceneLevelsBordered) Init(data any, window *Window) {
 	// init
-	level := newLevel(scene.levelSettings, scene.playerSettings)
-	level.init(window)
-	scene.level = level
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
 }
 
 func (scene *SceneLevelsBordered) GetId() SceneId {
 	return LevelBordered
 }
 
+func (scene *SceneLevelsBordered) GetLevel() *Level {
+	return &scene.level
+}
+
 func (scene *SceneLevelsBordered) HandleUserInput(window *Window) {
 	scene.level.handleUserInput(window)
 }
 
 func (scene *SceneLevelsBordered) Update(window *Window) (SceneId, any) {
-	nextSceneId := scene.GetId()
-	var levelData any = nil
-	if scene.level.status != Stopped {
-		scene.level.update(window)
-		if scene.level.status == Finished {
-			nextSceneId = Transition
-			levelData = &scene.level
-		}
-	}
-	return nextSceneId, levelData
+	return scene.level.updateScene(window)
 }
 
 func (scene *SceneLevelsBordered) Draw(window *Window) {
@@ -2,13 +2,17 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"slices"
+	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 type LevelStatus = uint8
 type ActionEnum = uint8
+type FinishReason = uint8
 type Player = uint8
 
 const (
@@ -22,6 +26,16 @@ const (
 	StoneAimed ActionEnum = iota
 	StoneHit   ActionEnum = iota
 )
+const (
+	NotFinished              FinishReason = iota
+	FinishedByKnockout       FinishReason = iota
+	FinishedByStoneCount     FinishReason = iota
+	FinishedByLife           FinishReason = iota
+	FinishedByTurn           FinishReason = iota
+	FinishedByForfeit        FinishReason = iota
+	FinishedByBorderDistance FinishReason = iota
+	FinishedBySuddenDeath    FinishReason = iota
+)
 const (
 	PlayerOne        Player = iota
 	PlayerTwo        Player = iota
@@ -39,6 +53,14 @@ type Stone struct {
 	life     float32
 	pos      rl.Vector2
 	velocity rl.Vector2
+	kind     StoneKind
+	shielded bool // a shielded stone that wasn't hit yet
+	// power-up effects
+	charged        bool  // the next hit deals double damage
+	massBoostTurns uint8 // turns left with the boosted mass
+	frozenTurns    uint8 // turns left it can't be played
+	portalCooldown float32
+	locked         bool // sits out the current turn
 }
 
 func newStone(stoneId uint8, x, y float32, radius, mass float32, playerId Player) Stone {
@@ -60,6 +82,8 @@ type PlayerSettings struct {
 	outerRingColor rl.Color
 	lifeColor      rl.Color
 	rocketColor    rl.Color
+	ringStyle      RingStyle
+	emblem         Emblem
 	label          string
 }
 
@@ -75,9 +99,23 @@ type LevelSettings struct {
 	isTimed             bool
 	sceneId             SceneId
 	stonesPerPlayer     uint8
-	totalSecondsAllowed uint8
+	timeAllowed         time.Duration
 	backgroundColor     rl.Color
-	boundary            rl.Rectangle
+	arena               Arena // bordered levels only
+	rules               LevelRules
+	tiebreaks           []Tiebreak     // timed levels only, tried in order when the time runs out
+	suddenDeath         bool           // timed levels only, a tie after all the tiebreaks goes to overtime
+	surfaces            []SurfaceZone  // ice, sand, boost pads and conveyors
+	shrink              ShrinkSettings // bordered levels only
+	pits                []Pit          // the stones falling in are lost
+	gates               []Gate         // one-way walls
+	portals             []PortalPair
+	wells               []GravityWell // attractors and repulsors
+	lockedPerTurn       uint8         // this many of the active player's stones are locked every turn
+	turnRules           TurnRules     // extra turns and fouls, judged when the stones stop
+	noTrajectoryPreview bool          // only the power meter is shown while aiming
+	stoneKinds          []StoneKind   // the formations are drawn from these, normal stones only when empty
+	powerUps            []PowerUpKind // the ones that can show up during the match, none when empty
 }
 
 type Level struct {
@@ -86,7 +124,7 @@ type Level struct {
 	status                         LevelStatus
 	action                         ActionEnum
 	lastTimeUpdated                float32
-	totalTimeRunning               float32
+	clock                          MatchClock
 	selectedStoneRotAnimationAngle float32 // TODO: do we really need this?
 	aimVectorStart                 rl.Vector2
 	aimVectorForwardExtensionEnd   rl.Vector2
@@ -95,22 +133,47 @@ type Level struct {
 	hitStoneMoving                 *Stone
 	score                          [TotalPlayerCount]uint8
 	playerSettings                 [TotalPlayerCount]PlayerSettings
+	matchLog                       MatchLog
+	achievementTracker             achievementTracker
+	finishReason                   FinishReason
+	rng                            levelRng
+	pauseMenu                      PauseMenu
+	directAim                      bool                   // the selected stone is aimed with the keyboard or a gamepad, the mouse is ignored
+	touchId                        int32                  // the finger aiming the selected stone, noTouch otherwise
+	aimAngle                       float32                // direct aiming: the direction of the shot in radians
+	aimPower                       float32                // direct aiming: 0..1 of MaxPullLengthAllowed
+	extraTurn                      [TotalPlayerCount]bool // picked up an extra turn, it's given when the stones stop
+	skipTurn                       [TotalPlayerCount]bool // fouled, the next turn goes to the opponent
+	turnPending                    bool                   // a shot was fired, the turn changes once the stones stop
+	ownsSave                       bool                   // the save file holds this match, it was resumed from it or saved into it
+	turnNotice                     string                 // the foul or the extra turn of the last shot
+	turnNoticePlayer               Player                 // the one the notice is about
+	turnNoticeAt                   float32                // on the match clock
+	overtime                       bool                   // the time ran out on a tie, the next stone lost decides
+	overtimeScore                  [TotalPlayerCount]uint8
+	arenaFull                      Arena         // the full size of the arena, it's scaled down as the arena shrinks
+	arenaScale                     float32       // where the walls are
+	arenaTarget                    float32       // where the walls are going
+	nextShrinkAt                   time.Duration // the next timed shrink
 	// collection of items
 	stones       []Stone
 	allParticles []Particle
 	allShards    []Shard
+	powerUps     []PowerUp
 }
 
 func newLevel(levelSettings LevelSettings, playerSettings [TotalPlayerCount]PlayerSettings) Level {
+	rng := newLevelRng(rand.Uint64(), rand.Uint64())
+
 	playerTurn := PlayerOne
-	if rand.Float32() > 0.5 {
+	if rng.Float32() > 0.5 {
 		playerTurn = PlayerTwo
 	}
 
 	return Level{
 		status:                         Uninitialized,
 		lastTimeUpdated:                0.0,
-		totalTimeRunning:               0.0,
+		clock:                          newMatchClock(levelSettings.timeAllowed),
 		stones:                         []Stone{},
 		selectedStone:                  nil,
 		selectedStoneRotAnimationAngle: 0.0,
@@ -118,6 +181,7 @@ func newLevel(levelSettings LevelSettings, playerSettings [TotalPlayerCount]Play
 		action:                         NoAction,
 		allParticles:                   []Particle{},
 		allShards:                      []Shard{},
+		powerUps:                       []PowerUp{},
 		stonesAreStill:                 true,
 		score: [TotalPlayerCount]uint8{
 			PlayerOne: levelSettings.stonesPerPlayer,
@@ -126,16 +190,63 @@ func newLevel(levelSettings LevelSettings, playerSettings [TotalPlayerCount]Play
 		playerTurn:     playerTurn,
 		playerSettings: playerSettings,
 		levelSettings:  levelSettings,
+		matchLog:       newMatchLog(),
+		rng:            rng,
+		touchId:        noTouch,
+		arenaFull:      levelSettings.arena,
+		arenaScale:     1,
+		arenaTarget:    1,
+		nextShrinkAt:   levelSettings.shrink.every,
 	}
 }
 
+// startLevel - a fresh level, or the resumed one when the scene was started with a saved match
+// or with the paused level coming back from the options
+func startLevel(levelSettings LevelSettings, playerSettings [TotalPlayerCount]PlayerSettings, data any, window *Window) Level {
+	if paused, ok := data.(*Level); ok {
+		// the text size may have changed in the options
+		paused.layout(window)
+		return *paused
+	}
+
+	playerSettings = config.applyToPlayers(playerSettings)
+	playerSettings = stoneProfiles.applyToPlayers(playerSettings)
+
+	level := newLevel(levelSettings, playerSettings)
+	level.init(window)
+
+	if saved, ok := data.(*savedMatch); ok {
+		if err := level.restore(saved, window); err != nil {
+			rl.TraceLog(rl.LogWarning, "the saved match could not be resumed: %v", err)
+			level = newLevel(levelSettings, playerSettings)
+			level.init(window)
+		} else {
+			level.ownsSave = true
+		}
+	}
+
+	return level
+}
+
 func (level *Level) init(window *Window) {
-	level.stones = generateStones(level.levelSettings, window)
+	level.setStones(generateStones(level.levelSettings, window, level.rng))
+	level.lockStones()
 	level.status = Initialized
 }
 
+// setStones - places the stones on the field and starts the match log from their life points
+func (level *Level) setStones(stones []Stone) {
+	level.stones = stones
+	level.matchLog = newMatchLog()
+	level.achievementTracker = newAchievementTracker()
+	level.finishReason = NotFinished
+	for _, stone := range stones {
+		level.matchLog.initialLife[stone.playerId] += stone.life
+	}
+}
+
 // generates a random formation of 6 stones in a 3x4 matrix
-func generateFormation(stonesPerPlayer uint8) [12]bool {
+func generateFormation(stonesPerPlayer uint8, rng levelRng) [12]bool {
 	const MAX_STONE_COUNT = 12
 	a := [MAX_STONE_COUNT]bool{}
 
@@ -143,17 +254,22 @@ func generateFormation(stonesPerPlayer uint8) [12]bool {
 		a[i] = true
 	}
 
-	rand.Shuffle(MAX_STONE_COUNT, func(i, j int) { a[i], a[j] = a[j], a[i] })
+	rng.Shuffle(MAX_STONE_COUNT, func(i, j int) { a[i], a[j] = a[j], a[i] })
 	return a
 }
 
-func generateStones(levelSettings LevelSettings, window *Window) []Stone {
+func generateStones(levelSettings LevelSettings, window *Window, rng levelRng) []Stone {
 	stones := []Stone{}
 
 	screenWidth, screenHeight := window.GetScreenDimensions()
 
-	f1 := generateFormation(levelSettings.stonesPerPlayer)
-	f2 := generateFormation(levelSettings.stonesPerPlayer)
+	f1 := generateFormation(levelSettings.stonesPerPlayer, rng)
+	f2 := generateFormation(levelSettings.stonesPerPlayer, rng)
+
+	// the same kinds on both sides, each in its own order
+	k1 := pickStoneKinds(levelSettings, rng)
+	k2 := slices.Clone(k1)
+	rng.Shuffle(len(k2), func(i, j int) { k2[i], k2[j] = k2[j], k2[i] })
 
 	ids := uint8(0)
 
@@ -165,13 +281,15 @@ func generateStones(levelSettings LevelSettings, window *Window) []Stone {
 
 			if f1[pos] {
 				w1 := screenWidth * float32(x) * 0.125
-				stones = append(stones, newStone(ids, w1, h, StoneRadius, 1, PlayerOne))
+				stones = append(stones, newStoneOfKind(ids, w1, h, k1[0], PlayerOne, levelSettings.rules))
+				k1 = k1[1:]
 				ids++
 			}
 
 			if f2[pos] {
 				w2 := screenWidth*float32(x)*0.125 + screenWidth*0.5
-				stones = append(stones, newStone(ids, w2, h, StoneRadius, 1, PlayerTwo))
+				stones = append(stones, newStoneOfKind(ids, w2, h, k2[0], PlayerTwo, levelSettings.rules))
+				k2 = k2[1:]
 				ids++
 			}
 		}
@@ -230,8 +348,8 @@ func resolveCollision(a, b *Stone) {
 	b.velocity = vbV
 }
 
-func calcVelocity(s *Stone) {
-	s.velocity = rl.Vector2Scale(s.velocity, VelocityDampingFactor)
+func calcVelocity(s *Stone, damping float32) {
+	s.velocity = rl.Vector2Scale(s.velocity, damping)
 	if rl.Vector2Length(s.velocity) < VelocityThresholdToStop {
 		s.velocity.X = 0
 		s.velocity.Y = 0
@@ -248,6 +366,11 @@ func (level *Level) checkStonesForMovements() {
 			return
 		}
 	}
+
+	// a shot too weak to move anything still ends the turn
+	if (!level.stonesAreStill || level.turnPending) && level.status == Initialized {
+		level.beginTurn()
+	}
 	level.stonesAreStill = true
 }
 
@@ -257,51 +380,55 @@ type collisionPair struct {
 	magnitude      float32
 }
 
+// bounceOffWalls - pushes the stone out of the walls it's in and reflects it off of them.
+// returns the part of the velocity that went into the walls, for the angle of the hit, and whether it bounced at all
+func bounceOffWalls(a *Stone, contacts []WallContact) (float32, bool) {
+	intoWall := float32(0)
+	bounced := false
+	moved := rl.NewVector2(0, 0)
+
+	for _, contact := range contacts {
+		// two edges meeting at a corner report the same overlap, it's only pushed out once
+		if depth := contact.depth - rl.Vector2DotProduct(moved, contact.normal); depth > 0 {
+			push := rl.Vector2Scale(contact.normal, depth)
+			a.pos = rl.Vector2Add(a.pos, push)
+			moved = rl.Vector2Add(moved, push)
+		}
+
+		// reflected off the wall's normal, unless it's already moving away from it
+		if vn := rl.Vector2DotProduct(a.velocity, contact.normal); vn < 0 {
+			a.velocity = reflect(a.velocity, contact.normal)
+			intoWall = max(intoWall, -vn)
+			bounced = true
+		}
+	}
+
+	return intoWall, bounced
+}
+
 func (level *Level) resolveWallCollision(a *Stone, window *Window) {
-	boundary := level.levelSettings.boundary
-
-	wallCollision := false
-
-	var collisionPoint rl.Vector2
-
-	if a.pos.X-a.radius < boundary.X {
-		pd := boundary.X - (a.pos.X - a.radius)
-		a.pos.X += pd
-		a.velocity.X *= -1
-		wallCollision = true
-		collisionPoint = rl.NewVector2(boundary.X, a.pos.Y)
-	} else if a.pos.X+a.radius > boundary.X+boundary.Width {
-		pd := (a.pos.X + a.radius) - (boundary.X + boundary.Width)
-		a.pos.X -= pd
-		a.velocity.X *= -1
-		wallCollision = true
-		collisionPoint = rl.NewVector2(boundary.X+boundary.Width, a.pos.Y)
-	}
-
-	if a.pos.Y-a.radius < boundary.Y {
-		pd := boundary.Y - (a.pos.Y - a.radius)
-		a.pos.Y += pd
-		a.velocity.Y *= -1
-		wallCollision = true
-		collisionPoint = rl.NewVector2(a.pos.X, boundary.Y)
-	} else if a.pos.Y+a.radius > boundary.Y+boundary.Height {
-		pd := (a.pos.Y + a.radius) - (boundary.Y + boundary.Height)
-		a.pos.Y -= pd
-		a.velocity.Y *= -1
-		wallCollision = true
-		collisionPoint = rl.NewVector2(a.pos.X, boundary.Y+boundary.Height)
-	}
-
-	if wallCollision {
+	contacts := level.wallContacts(a)
+	if len(contacts) == 0 {
+		return
+	}
+
+	collisionPoint := contacts[0].point
+	intoWall, bounced := bounceOffWalls(a, contacts)
+
+	// a stone already moving away from the wall isn't a hit
+	if bounced {
 		speedDiff := rl.Vector2Length(a.velocity)
-		amount := rl.Clamp(speedDiff, 0, MaxPushVelocityAllowed) * 2
-		a.life -= amount * 0.3 // TODO: maybe it should also depend on the angle the stone is hitting the wall
+		headOn := intoWall / speedDiff
 
 		level.hitStoneMoving = nil
 
 		collisionMagnitude := 2 * speedDiff / MaxPushVelocityAllowed
-		for i := float32(0.0); i < 100; i += 0.5 {
-			shardColor := level.playerSettings[a.playerId].primaryColor
+
+		level.matchLog.record(level, MatchEvent{kind: WallHit, stoneId: a.id, playerId: a.playerId, amount: collisionMagnitude})
+		level.damageStone(a, level.levelSettings.rules.wallDamage(speedDiff, headOn), nil)
+
+		for i := float32(0.0); i < 100; i += shardStep() {
+			shardColor := level.lookOf(a.playerId).primaryColor
 			part := NewShard(
 				collisionPoint,
 				3.6*i,
@@ -319,12 +446,14 @@ func (level *Level) resolveWallCollision(a *Stone, window *Window) {
 }
 
 func (level *Level) update(window *Window) {
+	level.updateArena(rl.GetFrameTime())
 
-	if level.levelSettings.isBordered {
+	if level.levelSettings.isBordered || len(level.levelSettings.gates) > 0 {
 		allStonesCount := len(level.stones)
 		for i := range allStonesCount {
 			a := &level.stones[i]
-			if a.isDead {
+			// the ones the walls closed in on are destroyed below
+			if a.isDead || level.caughtOutside(a) || level.sweptOver(a) {
 				continue
 			}
 			level.resolveWallCollision(a, window)
@@ -361,25 +490,40 @@ func (level *Level) update(window *Window) {
 	for _, p := range collidingPairs {
 		speedDiff := rl.Vector2Length(rl.Vector2Subtract(p.a.velocity, p.b.velocity))
 		aIsFaster := rl.Vector2Length(p.a.velocity) > rl.Vector2Length(p.b.velocity)
-		amount := rl.Clamp(speedDiff, 0, MaxPushVelocityAllowed) * 2
+		amount := level.levelSettings.rules.impactDamage(speedDiff)
+		attackerShare := level.levelSettings.rules.attackerShare
+		defenderShare := level.levelSettings.rules.defenderShare
 
 		resolvePenetrationDepth(p.a, p.b)
 		resolveCollision(p.a, p.b)
 		level.hitStoneMoving = nil
 
+		level.matchLog.record(level, MatchEvent{
+			kind:     StoneCollided,
+			stoneId:  p.a.id,
+			playerId: p.a.playerId,
+			otherId:  p.b.id,
+			byStone:  true,
+			amount:   p.magnitude,
+		})
+
+		// heavier kinds hit harder, whichever side they're on
+		aDamage := kindInfo(p.a).damage
+		bDamage := kindInfo(p.b).damage
+
 		if aIsFaster {
-			p.b.life -= amount
-			p.a.life -= amount * 0.2
+			level.damageStone(p.b, amount*defenderShare*aDamage*spendCharge(p.a), p.a)
+			level.damageStone(p.a, amount*attackerShare*bDamage, p.b)
 		} else {
-			p.a.life -= amount
-			p.b.life -= amount * 0.2
+			level.damageStone(p.a, amount*defenderShare*bDamage*spendCharge(p.b), p.b)
+			level.damageStone(p.b, amount*attackerShare*aDamage, p.a)
 		}
 
-		for i := float32(0.0); i < 100; i += 0.5 {
+		for i := float32(0.0); i < 100; i += shardStep() {
 			// TODO: shard size should depend on the screen size
-			shardColor := level.playerSettings[p.a.playerId].primaryColor
+			shardColor := level.lookOf(p.a.playerId).primaryColor
 			if rand.Float32() > 0.5 {
-				shardColor = level.playerSettings[p.b.playerId].primaryColor
+				shardColor = level.lookOf(p.b.playerId).primaryColor
 			}
 			part := NewShard(
 				p.collisionPoint,
@@ -407,31 +551,59 @@ func (level *Level) update(window *Window) {
 		if stone.isDead {
 			continue
 		}
+		level.applyGravity(stone)
 		stone.pos = rl.Vector2Add(stone.pos, stone.velocity)
-		calcVelocity(stone)
+		level.applySurface(stone)
+		level.enterPortals(stone)
 
-		if !rl.CheckCollisionPointRec(stone.pos, screenRect) || stone.life <= 0 {
+		if !rl.CheckCollisionPointRec(stone.pos, screenRect) || stone.life <= 0 || level.caughtOutside(stone) || level.fellInPit(stone) {
 			stone.isDead = true
 			newlyDeadStonesIx = append(newlyDeadStonesIx, i)
+			level.matchLog.record(level, MatchEvent{kind: StoneDied, stoneId: stone.id, playerId: stone.playerId, amount: max(stone.life, 0)})
 			if level.hitStoneMoving == stone {
 				level.hitStoneMoving = nil
 			}
 		}
 	}
 
+	if len(newlyDeadStonesIx) > 0 {
+		level.unlockIfStuck()
+	}
+
+	level.collectPowerUps()
+	level.updatePortals()
+
 	if level.selectedStone != nil {
 		strength := rl.Vector2Distance(level.aimVectorStart, level.selectedStone.pos)
 		strength = rl.Clamp(MaxPullLengthAllowed, 0, strength)
 		level.selectedStoneRotAnimationAngle += rl.GetFrameTime() * 3 * strength
 	}
 
+	{ // explosives go off and splitters break, only when they ran out of life on the field
+		spawned := []Stone{}
+		for _, ix := range newlyDeadStonesIx {
+			stone := &level.stones[ix]
+			if stone.life > 0 {
+				continue
+			}
+
+			switch stone.kind {
+			case ExplosiveStone:
+				level.explode(stone, window)
+			case SplitterStone:
+				spawned = append(spawned, level.split(stone)...)
+			}
+		}
+		level.addStones(spawned)
+	}
+
 	{ // creates the shards at the position of the dead stone
 		for _, ix := range newlyDeadStonesIx {
 			stone := level.stones[ix]
 
-			shardColor := level.playerSettings[stone.playerId].primaryColor
+			shardColor := level.lookOf(stone.playerId).primaryColor
 
-			for i := float32(0.0); i < 300; i += 0.5 {
+			for i := float32(0.0); i < 300; i += shardStep() {
 				part := NewShard(
 					stone.pos,
 					3.6*i,
@@ -450,30 +622,12 @@ func (level *Level) update(window *Window) {
 	}
 
 	if level.action == StoneHit {
-		// find the diff between the selected stone and where the mouse is
-		diff := rl.Vector2Subtract(level.selectedStone.pos, level.aimVectorStart)
-		// find the length of the diff vector
-		length := rl.Vector2Length(diff)
-		// make sure the length is bounded
-		length = rl.Clamp(length, 0, MaxPullLengthAllowed)
-		// the max speed we allow is MaxPushVelocityAllowed,
-		// so we calculate the speed based on the distance from the selected stone
-		speed := MaxPushVelocityAllowed * (length / MaxPullLengthAllowed)
-		// normalize the diff vector
-		// scale it up based on the speed
-		v := rl.Vector2Scale(rl.Vector2Normalize(diff), speed)
-
-		level.selectedStone.velocity = v
-
-		level.action = NoAction
+		level.selectedStone.velocity = level.shotVelocity()
+		level.matchLog.record(level, MatchEvent{kind: ShotFired, stoneId: level.selectedStone.id, playerId: level.selectedStone.playerId})
+
 		level.hitStoneMoving = level.selectedStone
-		level.selectedStone = nil
-		level.selectedStoneRotAnimationAngle = 0
-		if level.playerTurn == PlayerOne {
-			level.playerTurn = PlayerTwo
-		} else {
-			level.playerTurn = PlayerOne
-		}
+		level.cancelAim()
+		level.turnPending = true
 	}
 
 	{
@@ -481,7 +635,7 @@ func (level *Level) update(window *Window) {
 		stone := level.hitStoneMoving
 
 		if stone != nil {
-			rocketColor := level.playerSettings[stone.playerId].rocketColor
+			rocketColor := level.lookOf(stone.playerId).rocketColor
 
 			generalAngle := (rl.Vector2Angle(
 				rl.Vector2Normalize(stone.velocity),
@@ -490,7 +644,7 @@ func (level *Level) update(window *Window) {
 
 			life := 0.3 * (rl.Vector2Length(stone.velocity)) / 15
 
-			for range 25 {
+			for range particleCount(25) {
 				angle := generalAngle + float32((rand.Intn(20) - 10))
 
 				level.allParticles = append(level.allParticles, NewParticle(
@@ -547,9 +701,6 @@ func (level *Level) update(window *Window) {
 		scorePlayerOne := 0
 		scorePlayerTwo := 0
 
-		totalStoneLifePointsPlayerOne := float32(0.0)
-		totalStoneLifePointsPlayerTwo := float32(0.0)
-
 		for _, stone := range level.stones {
 			if stone.isDead {
 				continue
@@ -557,12 +708,10 @@ func (level *Level) update(window *Window) {
 
 			if stone.playerId == PlayerOne {
 				scorePlayerOne += 1
-				totalStoneLifePointsPlayerOne += stone.life
 			}
 
 			if stone.playerId == PlayerTwo {
 				scorePlayerTwo += 1
-				totalStoneLifePointsPlayerTwo += stone.life
 			}
 		}
 
@@ -570,34 +719,36 @@ func (level *Level) update(window *Window) {
 		level.score[PlayerTwo] = uint8(scorePlayerTwo)
 
 		if level.levelSettings.isTimed {
-			timeLeft := level.levelSettings.totalSecondsAllowed - uint8(level.totalTimeRunning)
-			if timeLeft == 0 {
-				if level.score[PlayerOne] > level.score[PlayerTwo] {
-					level.score[PlayerTwo] = 0
-				} else if level.score[PlayerOne] < level.score[PlayerTwo] {
-					level.score[PlayerOne] = 0
-				} else {
-					// TODO: these can be elaborate than this simple heuristic, but for now it's good enough
-					if totalStoneLifePointsPlayerOne > totalStoneLifePointsPlayerTwo {
-						level.score[PlayerTwo] = 0
-					} else if totalStoneLifePointsPlayerOne < totalStoneLifePointsPlayerTwo {
-						level.score[PlayerOne] = 0
-					} else {
-						level.score[level.playerTurn] = 0 // if everything is equal when the time runs out...the one whose turn it is loses.
-					}
+			if level.overtime {
+				level.checkOvertime()
+			} else {
+				if level.clock.isUp() {
+					level.resolveTimeUp()
 				}
 			}
 		}
 
 		if level.score[PlayerOne]*level.score[PlayerTwo] == 0 {
+			if level.finishReason == NotFinished {
+				level.finishReason = FinishedByKnockout
+			}
 			level.status = Finished
 			level.playerTurn = PlayerOne
+			level.matchLog.record(level, MatchEvent{kind: MatchEnded, playerId: level.winner()})
 		}
 	}
 
 	level.checkStonesForMovements()
 	level.lastTimeUpdated = float32(rl.GetTime())
-	level.totalTimeRunning += rl.GetFrameTime()
+	level.clock.tick(rl.GetFrameTime())
+}
+
+// winner - only meaningful once the level is finished
+func (level *Level) winner() Player {
+	if level.score[PlayerOne] == 0 {
+		return PlayerTwo
+	}
+	return PlayerOne
 }
 
 func (level *Level) setAimVectorStart(aimVectorStart rl.Vector2) {
@@ -608,43 +759,53 @@ func (level *Level) setAimVectorStart(aimVectorStart rl.Vector2) {
 }
 
 func (level *Level) handleUserInput(window *Window) {
-	if rl.IsKeyDown(rl.KeyS) {
+	canPause := level.levelSettings.sceneId != Main
+	if canPause && inputBindings.isPressedByAnyone(PauseGame) {
 		if level.status == Stopped {
-			level.status = Initialized
+			level.resume()
 		} else {
-			level.status = Stopped
+			level.pause(window)
 		}
+		return
 	}
 
-	if level.status != Stopped {
+	if level.status == Stopped {
+		level.pauseMenu.handleUserInput()
+	} else {
 		if level.playerSettings[level.playerTurn].isCpu {
 			level.handleCpuMove(window)
 		} else {
-			level.handleMouseMove()
+			level.handleDirectAim()
+			if touchInput.inUse || level.touchId != noTouch {
+				level.handleTouchMove()
+			} else {
+				level.handleMouseMove()
+			}
 		}
 	}
 }
 
 func (level *Level) handleMouseMove() {
+	if level.directAim {
+		// only a click takes the control back, the mouse can drift while aiming with the keys
+		if !rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
+			return
+		}
+		level.cancelAim()
+	}
+
 	level.setAimVectorStart(rl.GetMousePosition())
 
 	if rl.IsMouseButtonDown(rl.MouseButtonLeft) && level.stonesAreStill && level.selectedStone == nil {
-		for i, stone := range level.stones {
-			if stone.isDead {
-				continue
-			}
-			if level.playerTurn == stone.playerId && rl.CheckCollisionPointCircle(level.aimVectorStart, stone.pos, stone.radius) {
-				level.selectedStone = &level.stones[i]
-				level.action = StoneAimed
-				break
-			}
+		if stone := level.stoneUnder(level.aimVectorStart); stone != nil {
+			level.selectedStone = stone
+			level.action = StoneAimed
 		}
 	}
 
 	if rl.IsMouseButtonReleased(rl.MouseButtonLeft) && level.action == StoneAimed {
 		if rl.CheckCollisionPointCircle(level.aimVectorStart, level.selectedStone.pos, StoneSelectionCancelCircleRadius) {
-			level.selectedStone = nil
-			level.action = NoAction
+			level.cancelAim()
 		} else {
 			level.action = StoneHit
 		}
@@ -652,34 +813,152 @@ func (level *Level) handleMouseMove() {
 
 }
 
+func (level *Level) cancelAim() {
+	level.selectedStone = nil
+	level.action = NoAction
+	level.selectedStoneRotAnimationAngle = 0
+	level.directAim = false
+	level.touchId = noTouch
+}
+
+// handleDirectAim - selecting and aiming a stone with the keyboard or a gamepad.
+// the stick is pulled back like the mouse, the buttons rotate the aim and change the power.
+// holding the fine aim button slows the rotation and the power changes down for precise shots
+func (level *Level) handleDirectAim() {
+	if !level.stonesAreStill {
+		return
+	}
+
+	gamepad := gamepadOf(level.playerTurn)
+
+	if inputBindings.isPressedBy(SelectNextStone, gamepad) {
+		level.cycleSelectedStone(1)
+	}
+
+	if inputBindings.isPressedBy(SelectPreviousStone, gamepad) {
+		level.cycleSelectedStone(-1)
+	}
+
+	stick := gamepadStick(gamepad)
+	pulled := rl.Vector2Length(stick)
+	if pulled > 0 && level.selectedStone == nil {
+		level.cycleSelectedStone(1)
+	}
+
+	if !level.directAim || level.action != StoneAimed {
+		return
+	}
+
+	if inputBindings.isPressedBy(Cancel, gamepad) {
+		level.cancelAim()
+		return
+	}
+
+	if pulled > 0 {
+		// the shot goes the opposite way of the pull
+		level.aimAngle = float32(math.Atan2(float64(-stick.Y), float64(-stick.X)))
+		level.aimPower = pulled
+	}
+
+	dt := rl.GetFrameTime()
+	rotationSpeed := float32(math.Pi) // half a turn per second
+	powerSpeed := float32(0.75)
+	if inputBindings.isDownBy(FineAim, gamepad) {
+		rotationSpeed /= 12
+		powerSpeed /= 12
+	}
+
+	if inputBindings.isDownBy(RotateAimLeft, gamepad) {
+		level.aimAngle -= rotationSpeed * dt
+	}
+	if inputBindings.isDownBy(RotateAimRight, gamepad) {
+		level.aimAngle += rotationSpeed * dt
+	}
+	if inputBindings.isDownBy(IncreasePower, gamepad) {
+		level.aimPower += powerSpeed * dt
+	}
+	if inputBindings.isDownBy(DecreasePower, gamepad) {
+		level.aimPower -= powerSpeed * dt
+	}
+	level.aimPower = rl.Clamp(level.aimPower, 0, 1)
+
+	level.applyDirectAim()
+
+	if inputBindings.isPressedBy(Fire, gamepad) && level.aimPower > 0 {
+		level.action = StoneHit
+	}
+}
+
+// cycleSelectedStone - moves the selection through the live stones of the current player
+func (level *Level) cycleSelectedStone(step int) {
+	candidates := []int{}
+	current := -1
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if !stone.canBePlayed() || stone.playerId != level.playerTurn {
+			continue
+		}
+		if stone == level.selectedStone {
+			current = len(candidates)
+		}
+		candidates = append(candidates, i)
+	}
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	next := 0
+	if current != -1 {
+		next = (current + step + len(candidates)) % len(candidates)
+	} else if step < 0 {
+		next = len(candidates) - 1
+	}
+
+	if !level.directAim {
+		// start by aiming at the opponent's half
+		level.aimAngle = 0
+		if level.playerTurn == PlayerTwo {
+			level.aimAngle = math.Pi
+		}
+		level.aimPower = 0.5
+	}
+
+	level.selectedStone = &level.stones[candidates[next]]
+	level.action = StoneAimed
+	level.directAim = true
+	level.applyDirectAim()
+}
+
+// applyDirectAim - turns the angle and the power into the same pull-back vector the mouse produces
+func (level *Level) applyDirectAim() {
+	angle := float64(level.aimAngle)
+	shotDirection := rl.NewVector2(float32(math.Cos(angle)), float32(math.Sin(angle)))
+	pull := rl.Vector2Scale(shotDirection, -level.aimPower*MaxPullLengthAllowed)
+	level.setAimVectorStart(rl.Vector2Add(level.selectedStone.pos, pull))
+}
+
 func (level *Level) handleCpuMove(window *Window) {
 	if !level.stonesAreStill || level.status == Finished {
 		return
 	}
 
-	actor, target := cpuSearchBestOption(level, window)
-
-	if actor == nil || target == nil {
+	choice, ok := cpuSearchBestOption(level, window)
+	if !ok {
 		return
 	}
 
+	actor := choice.actor
 	level.selectedStone = actor
 	level.action = StoneHit
-	clampedV := rl.Vector2Subtract(target.pos, actor.pos)
-	clampedV = rl.Vector2ClampValue(clampedV, 0.0, MaxPullLengthAllowed)
-	clampedV = rl.Vector2Negate(clampedV)
-	clampedV = rl.Vector2Add(actor.pos, clampedV)
-
-	screenBoundary := level.levelSettings.boundary
-	boundaryLines := window.GetScreenBoundaryLines(screenBoundary)
-
-	if !rl.CheckCollisionPointRec(clampedV, screenBoundary) {
-		for _, line := range boundaryLines {
-			point, ok := getLineToLineIntersectionPoint(line[0], line[1], clampedV, actor.pos)
-			if ok {
-				clampedV = point
-				break
-			}
+	clampedV := level.cpuPull(actor, choice.aim, choice.travel)
+
+	// the pull can't reach past the walls, whatever their shape
+	playArea := level.playArea()
+	if !playArea.contains(clampedV) {
+		direction := rl.Vector2Normalize(rl.Vector2Subtract(clampedV, actor.pos))
+		if t, _, ok := playArea.rayCast(actor.pos, direction, 0); ok {
+			clampedV = rl.Vector2Add(actor.pos, rl.Vector2Scale(direction, t))
 		}
 	}
 
@@ -689,34 +968,42 @@ func (level *Level) handleCpuMove(window *Window) {
 func (level *Level) drawField(window *Window) {
 	screenWidth, screenHeight := window.GetScreenDimensions()
 
-	rl.ClearBackground(level.levelSettings.backgroundColor)
+	backgroundColor, lineColor := level.fieldColors()
+
+	rl.ClearBackground(backgroundColor)
+	level.drawSurfaces()
+	level.drawWells()
+	level.drawPits()
+	level.drawPortals()
+	level.drawArena(screenWidth, screenHeight)
 
 	if level.levelSettings.isBordered {
-		rl.DrawRectangleLinesEx(
-			level.levelSettings.boundary,
-			screenWidth/255,
-			dimWhite(125),
-		)
+		level.levelSettings.arena.draw(screenWidth/255, lineColor)
 	}
 
 	drawScore(screenWidth, screenHeight, level)
+	level.drawTurnNotice(screenWidth, screenHeight)
 
 	// draw the vertical centre line
 	rl.DrawLineEx(
 		rl.NewVector2(screenWidth/2, 0),
 		rl.NewVector2(screenWidth/2, screenHeight),
 		screenWidth/256,
-		dimWhite(125),
+		lineColor,
 	)
 
-	if level.levelSettings.isTimed {
-		timeLeft := level.levelSettings.totalSecondsAllowed - uint8(level.totalTimeRunning)
-		totalTimeTxt := fmt.Sprintf("%02d", timeLeft)
+	level.drawGates(lineColor)
+	level.drawPowerUps()
+
+	if level.levelSettings.isTimed && level.overtime {
+		level.drawOvertime(screenWidth, screenHeight, backgroundColor)
+	} else if level.levelSettings.isTimed {
+		totalTimeTxt := level.clock.display()
 
 		// this is used for the width of the timer
 		// so that it is not variable based on the time value itself.
 		// since the default Raylib font isn't monospaced, it will shrink in width when it is 19 vs when it is 22
-		totalTimeTxtMeasured := rl.MeasureTextEx(rl.GetFontDefault(), "00", FontSize/3, FontSize/30)
+		totalTimeTxtMeasured := rl.MeasureTextEx(rl.GetFontDefault(), level.clock.displayTemplate(), FontSize/3, FontSize/30)
 
 		measuredSize := rl.NewVector2(
 			totalTimeTxtMeasured.X*1.2,
@@ -729,13 +1016,13 @@ func (level *Level) drawField(window *Window) {
 		rl.DrawRectangleV(
 			rl.NewVector2(offsetX, offsetY),
 			measuredSize,
-			BG_COLOR,
+			backgroundColor,
 		)
 
 		rl.DrawRectangleLinesEx(
 			rl.NewRectangle(offsetX, offsetY, measuredSize.X, measuredSize.Y),
 			10,
-			dimWhite(125),
+			lineColor,
 		)
 
 		totalTimeTxtMeasured = rl.MeasureTextEx(rl.GetFontDefault(), totalTimeTxt, FontSize/3, FontSize/30)
@@ -749,7 +1036,7 @@ func (level *Level) drawField(window *Window) {
 			rl.NewVector2(offsetX, offsetY),
 			FontSize/3,
 			FontSize/30,
-			dimWhite(125),
+			lineColor,
 		)
 	}
 }
@@ -758,7 +1045,7 @@ func (level *Level) drawObjects() {
 	// draw the aim bubbles
 	if level.action == StoneAimed {
 		for i := float32(0.0); i <= 1.0; i += 0.1 {
-			amount := i + level.totalTimeRunning/10
+			amount := i + level.clock.seconds()/10
 			amount = amount - float32(int(amount))
 			point := rl.Vector2Lerp(level.selectedStone.pos, level.aimVectorForwardExtensionEnd, amount)
 			rl.DrawCircleV(point, StoneRadius*0.4*(1-amount), dimWhite(50))
@@ -771,6 +1058,8 @@ func (level *Level) drawObjects() {
 		drawStone(stone, level)
 	}
 
+	level.drawAimPreview()
+
 	// draw the aim line
 	if level.action == StoneAimed {
 		rl.DrawCircleV(level.selectedStone.pos, StoneSelectionCancelCircleRadius, dimWhite(60))
@@ -802,28 +1091,25 @@ func (level *Level) drawObjects() {
 func (level *Level) draw(window *Window) {
 	level.drawField(window)
 	level.drawObjects()
+
+	if level.status == Stopped {
+		level.pauseMenu.draw(window)
+	}
 }
 
 func drawStone(s *Stone, level *Level) {
 	if s.isDead {
 		return
 	}
-	playerSettings := level.playerSettings[s.playerId]
-
-	rl.DrawCircleV(s.pos, s.radius, playerSettings.primaryColor)
-
-	// the outer/border ring
-	rl.DrawRing(
-		s.pos,
-		s.radius*0.8,
-		s.radius*1.01,
-		0.0,
-		360.0,
-		0,
-		playerSettings.outerRingColor,
-	)
-
-	if level.stonesAreStill && s.playerId == level.playerTurn && !level.playerSettings[level.playerTurn].isCpu {
+	look := level.lookOf(s.playerId)
+	// the face shows the life as a percentage
+	drawStoneFace(s.pos, s.radius, level.lifeShare(s)*100, look)
+	drawStoneKind(s, level.clock.seconds())
+	drawStoneEffects(s, level.clock.seconds())
+	drawStoneLock(s)
+	drawPlayerGlyph(s.pos, s.radius, s.playerId, look.lifeColor)
+
+	if level.stonesAreStill && s.playerId == level.playerTurn && s.canBePlayed() && !level.playerSettings[level.playerTurn].isCpu {
 		// the "active player" ring
 		rl.DrawRing(
 			s.pos,
@@ -850,28 +1136,21 @@ func drawStone(s *Stone, level *Level) {
 				s.pos,
 				s.radius*1.1,
 				s.radius*1.4,
-				0.0+level.totalTimeRunning*10,
-				40.0+level.totalTimeRunning*10,
+				0.0+level.clock.seconds()*10,
+				40.0+level.clock.seconds()*10,
 				0,
 				dimWhite(100),
 			)
 		}
 
 	}
-
-	rl.DrawRing(
-		s.pos,
-		s.radius*0.5,
-		s.radius*0.8,
-		0.0,
-		360.0*s.life/100,
-		0,
-		playerSettings.lifeColor,
-	)
 }
 
 func drawScore(screenWidth, screenHeight float32, level *Level) {
 	color := dimWhite(60)
+	if config.Accessibility.HighContrast {
+		color = dimWhite(110)
+	}
 	labelP1 := level.playerSettings[PlayerOne].label
 	labelP2 := level.playerSettings[PlayerTwo].label
 
@@ -0,0 +1,64 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SceneLevelsGravity struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsGravity(window *Window) SceneLevelsGravity {
+	return SceneLevelsGravity{
+		levelSettings: LevelSettings{
+			sceneId:         LevelGravity,
+			stonesPerPlayer: 5,
+			backgroundColor: BG_COLOR,
+			rules:           defaultRules(),
+			// a well in the middle curves the shots across,
+			// the repulsors above and below it keep the shots around it from being easy
+			wells: []GravityWell{
+				{pos: rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.5), strength: 0.006, radius: CanvasHeight * 0.3},
+				{pos: rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.12), strength: -0.01, radius: CanvasHeight * 0.12},
+				{pos: rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.88), strength: -0.01, radius: CanvasHeight * 0.12},
+			},
+			// the curved shots that land on your own stones cost the shooter some life
+			turnRules: TurnRules{foulOnOwnContact: true, penalty: LifeFoulPenalty},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsGravity) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsGravity) GetId() SceneId {
+	return LevelGravity
+}
+
+func (scene *SceneLevelsGravity) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsGravity) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsGravity) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsGravity) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsGravity) Teardown(window *Window) {
+
+}
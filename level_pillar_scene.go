@@ -0,0 +1,72 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SceneLevelsPillar struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsPillar(window *Window) SceneLevelsPillar {
+	return SceneLevelsPillar{
+		levelSettings: LevelSettings{
+			sceneId:         LevelPillar,
+			stonesPerPlayer: 4,
+			backgroundColor: BG_COLOR,
+			isBordered:      true,
+			// the walls dip in at the middle of the top and the bottom, and a round pillar stands between them,
+			// the way across is around the pillar or banked off of it
+			arena: polygonArena(
+				rl.NewVector2(0, 0),
+				rl.NewVector2(CanvasWidth*0.4, 0),
+				rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.18),
+				rl.NewVector2(CanvasWidth*0.6, 0),
+				rl.NewVector2(CanvasWidth, 0),
+				rl.NewVector2(CanvasWidth, CanvasHeight),
+				rl.NewVector2(CanvasWidth*0.6, CanvasHeight),
+				rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.82),
+				rl.NewVector2(CanvasWidth*0.4, CanvasHeight),
+				rl.NewVector2(0, CanvasHeight),
+			).withHoles(
+				circleArena(rl.NewVector2(CanvasWidth/2, CanvasHeight/2), CanvasWidth*0.08),
+			),
+			rules: borderedRules(),
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsPillar) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsPillar) GetId() SceneId {
+	return LevelPillar
+}
+
+func (scene *SceneLevelsPillar) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsPillar) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsPillar) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsPillar) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsPillar) Teardown(window *Window) {
+
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SceneLevelsPockets struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsPockets(window *Window) SceneLevelsPockets {
+	return SceneLevelsPockets{
+		levelSettings: LevelSettings{
+			sceneId:         LevelPockets,
+			stonesPerPlayer: 4,
+			backgroundColor: BG_COLOR,
+			isBordered:      true,
+			arena:           rectangleArena(window.GetScreenBoundary()),
+			rules:           borderedRules(),
+			// pockets in the corners, like on a pool table
+			pits: []Pit{
+				{pos: rl.NewVector2(CanvasHeight*0.05, CanvasHeight*0.05), radius: CanvasHeight * 0.07},
+				{pos: rl.NewVector2(CanvasWidth-CanvasHeight*0.05, CanvasHeight*0.05), radius: CanvasHeight * 0.07},
+				{pos: rl.NewVector2(CanvasHeight*0.05, CanvasHeight*0.95), radius: CanvasHeight * 0.07},
+				{pos: rl.NewVector2(CanvasWidth-CanvasHeight*0.05, CanvasHeight*0.95), radius: CanvasHeight * 0.07},
+			},
+			// one-way gates on the middle line, the top one lets the stones to the right and the bottom one to the left
+			gates: []Gate{
+				{from: rl.NewVector2(CanvasWidth/2, CanvasHeight*0.08), to: rl.NewVector2(CanvasWidth/2, CanvasHeight*0.3)},
+				{from: rl.NewVector2(CanvasWidth/2, CanvasHeight*0.92), to: rl.NewVector2(CanvasWidth/2, CanvasHeight*0.7)},
+			},
+			// pool rules: pocketing an enemy shoots again, touching or sinking your own gives the turn away
+			turnRules: TurnRules{
+				extraTurnOnKnockout: true,
+				foulOnOwnContact:    true,
+				foulOnOwnKnockout:   true,
+				penalty:             SkipTurnFoulPenalty,
+			},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsPockets) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsPockets) GetId() SceneId {
+	return LevelPockets
+}
+
+func (scene *SceneLevelsPockets) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsPockets) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsPockets) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsPockets) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsPockets) Teardown(window *Window) {
+
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SceneLevelsPortals struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsPortals(window *Window) SceneLevelsPortals {
+	return SceneLevelsPortals{
+		levelSettings: LevelSettings{
+			sceneId:         LevelPortals,
+			stonesPerPlayer: 5,
+			backgroundColor: BG_COLOR,
+			rules:           defaultRules(),
+			// one pair wraps the middle of the field from top to bottom,
+			// the other takes the shots going down on one side and drops them from the top of the other
+			portals: []PortalPair{
+				{
+					ends: [2]Portal{
+						{pos: rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.12), radius: CanvasHeight * 0.06, facing: rl.NewVector2(0, 1)},
+						{pos: rl.NewVector2(CanvasWidth*0.5, CanvasHeight*0.88), radius: CanvasHeight * 0.06, facing: rl.NewVector2(0, -1)},
+					},
+					color: rl.NewColor(160, 100, 230, 255),
+				},
+				{
+					ends: [2]Portal{
+						{pos: rl.NewVector2(CanvasWidth*0.25, CanvasHeight*0.94), radius: CanvasHeight * 0.05, facing: rl.NewVector2(0, -1)},
+						{pos: rl.NewVector2(CanvasWidth*0.75, CanvasHeight*0.06), radius: CanvasHeight * 0.05, facing: rl.NewVector2(0, 1)},
+					},
+					color: rl.NewColor(60, 190, 170, 255),
+				},
+			},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsPortals) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsPortals) GetId() SceneId {
+	return LevelPortals
+}
+
+func (scene *SceneLevelsPortals) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsPortals) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsPortals) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsPortals) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsPortals) Teardown(window *Window) {
+
+}
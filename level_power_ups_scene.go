@@ -0,0 +1,52 @@
+package main
+
+type SceneLevelsPowerUps struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsPowerUps(window *Window) SceneLevelsPowerUps {
+	return SceneLevelsPowerUps{
+		levelSettings: LevelSettings{
+			sceneId:         LevelPowerUps,
+			stonesPerPlayer: 5,
+			backgroundColor: BG_COLOR,
+			rules:           defaultRules(),
+			powerUps:        []PowerUpKind{HealPowerUp, DoubleDamagePowerUp, ExtraTurnPowerUp, MassBoostPowerUp, FreezePowerUp},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsPowerUps) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsPowerUps) GetId() SceneId {
+	return LevelPowerUps
+}
+
+func (scene *SceneLevelsPowerUps) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsPowerUps) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsPowerUps) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsPowerUps) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsPowerUps) Teardown(window *Window) {
+
+}
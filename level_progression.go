@@ -3,7 +3,15 @@ package main
 var InitialLevel = LevelBasic
 
 var LevelProgression = map[SceneId]SceneId{
-	LevelBasic:     LevelBordered,
-	LevelBordered:  LevelTimeLimit,
-	LevelTimeLimit: LevelBasic,
+	LevelBasic:      LevelBordered,
+	LevelBordered:   LevelTimeLimit,
+	LevelTimeLimit:  LevelShrinking,
+	LevelShrinking:  LevelPortals,
+	LevelPortals:    LevelGravity,
+	LevelGravity:    LevelStoneKinds,
+	LevelStoneKinds: LevelPowerUps,
+	LevelPowerUps:   LevelSurfaces,
+	LevelSurfaces:   LevelPillar,
+	LevelPillar:     LevelPockets,
+	LevelPockets:    LevelBasic,
 }
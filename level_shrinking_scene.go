@@ -0,0 +1,63 @@
+package main
+
+type SceneLevelsShrinking struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsShrinking(window *Window) SceneLevelsShrinking {
+	return SceneLevelsShrinking{
+		levelSettings: LevelSettings{
+			sceneId:         LevelShrinking,
+			stonesPerPlayer: 5,
+			backgroundColor: BG_COLOR,
+			isBordered:      true,
+			// an octagon, the corners would be the safest spots otherwise.
+			// the back sides are open, a stone knocked out of them is lost
+			arena: chamferedArena(window.GetScreenBoundary(), CanvasHeight*0.2).withOpenSides(2, 6),
+			rules: borderedRules(),
+			// two of the stones sit out every turn, the walls don't wait for the one you wanted
+			lockedPerTurn: 2,
+			// every fourth shot takes a tenth off the arena, down to less than half of it
+			shrink: ShrinkSettings{
+				everyTurns: 4,
+				step:       0.1,
+				minScale:   0.4,
+			},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsShrinking) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsShrinking) GetId() SceneId {
+	return LevelShrinking
+}
+
+func (scene *SceneLevelsShrinking) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsShrinking) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsShrinking) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsShrinking) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsShrinking) Teardown(window *Window) {
+
+}
@@ -0,0 +1,53 @@
+package main
+
+type SceneLevelsStoneKinds struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsStoneKinds(window *Window) SceneLevelsStoneKinds {
+	return SceneLevelsStoneKinds{
+		levelSettings: LevelSettings{
+			sceneId:         LevelStoneKinds,
+			stonesPerPlayer: 5,
+			backgroundColor: BG_COLOR,
+			rules:           defaultRules(),
+			// every kind shows up, the normal ones a bit more often so each side keeps a few plain shots
+			stoneKinds: []StoneKind{NormalStone, NormalStone, HeavyStone, LightStone, ExplosiveStone, SplitterStone, ShieldedStone},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsStoneKinds) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsStoneKinds) GetId() SceneId {
+	return LevelStoneKinds
+}
+
+func (scene *SceneLevelsStoneKinds) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsStoneKinds) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsStoneKinds) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsStoneKinds) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsStoneKinds) Teardown(window *Window) {
+
+}
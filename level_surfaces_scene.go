@@ -0,0 +1,68 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SceneLevelsSurfaces struct {
+	level          Level
+	levelSettings  LevelSettings
+	playerSettings [TotalPlayerCount]PlayerSettings
+}
+
+func NewSceneLevelsSurfaces(window *Window) SceneLevelsSurfaces {
+	return SceneLevelsSurfaces{
+		levelSettings: LevelSettings{
+			sceneId:         LevelSurfaces,
+			stonesPerPlayer: 5,
+			backgroundColor: BG_COLOR,
+			isBordered:      true,
+			arena:           rectangleArena(window.GetScreenBoundary()),
+			rules:           borderedRules(),
+			// ice in the middle for fast crossings, sand along the back walls softens the bank shots,
+			// the boost pads throw the stones to the other side and the conveyors carry them along the walls
+			surfaces: []SurfaceZone{
+				{kind: IceSurface, area: rl.NewRectangle(0.45, 0.1, 0.1, 0.8)},
+				{kind: SandSurface, area: rl.NewRectangle(0, 0, 0.05, 1)},
+				{kind: SandSurface, area: rl.NewRectangle(0.95, 0, 0.05, 1)},
+				{kind: BoostPad, area: rl.NewRectangle(0.4, 0.25, 0.04, 0.12), direction: rl.NewVector2(1, 0)},
+				{kind: BoostPad, area: rl.NewRectangle(0.56, 0.63, 0.04, 0.12), direction: rl.NewVector2(-1, 0)},
+				{kind: ConveyorStrip, area: rl.NewRectangle(0.05, 0.02, 0.9, 0.06), direction: rl.NewVector2(1, 0)},
+				{kind: ConveyorStrip, area: rl.NewRectangle(0.05, 0.92, 0.9, 0.06), direction: rl.NewVector2(-1, 0)},
+			},
+		},
+		playerSettings: [TotalPlayerCount]PlayerSettings{
+			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
+			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
+		},
+	}
+}
+
+func (scene *SceneLevelsSurfaces) Init(data any, window *Window) {
+	// init
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
+}
+
+func (scene *SceneLevelsSurfaces) GetId() SceneId {
+	return LevelSurfaces
+}
+
+func (scene *SceneLevelsSurfaces) GetLevel() *Level {
+	return &scene.level
+}
+
+func (scene *SceneLevelsSurfaces) HandleUserInput(window *Window) {
+	scene.level.handleUserInput(window)
+}
+
+func (scene *SceneLevelsSurfaces) Update(window *Window) (SceneId, any) {
+	return scene.level.updateScene(window)
+}
+
+func (scene *SceneLevelsSurfaces) Draw(window *Window) {
+	scene.level.draw(window)
+}
+
+func (scene *SceneLevelsSurfaces) Teardown(window *Window) {
+
+}
@@ -1,5 +1,9 @@
 package main
 
+import (
+	"time"
+)
+
 type SceneLevelsTimeLimit struct {
 	level          Level
 	levelSettings  LevelSettings
@@ -9,11 +13,16 @@ type SceneLevelsTimeLimit struct {
 func NewSceneLevelsTimeLimit(window *Window) SceneLevelsTimeLimit {
 	return SceneLevelsTimeLimit{
 		levelSettings: LevelSettings{
-			sceneId:             LevelTimeLimit,
-			stonesPerPlayer:     5,
-			isTimed:             true,
-			totalSecondsAllowed: 45,
+			sceneId:         LevelTimeLimit,
+			stonesPerPlayer: 5,
+			isTimed:         true,
+			timeAllowed:     45 * time.Second,
+			// no time to study the angles, it's a reflex mode
+			noTrajectoryPreview: true,
 			backgroundColor:     BG_COLOR,
+			rules:               defaultRules(),
+			tiebreaks:           defaultTiebreaks,
+			suddenDeath:         true,
 		}, playerSettings: [TotalPlayerCount]PlayerSettings{
 			PlayerOne: getPlayer("you", HumanPlayerPalette1, false),
 			PlayerTwo: getPlayer("cpu", CpuPlayerPalette1, true),
@@ -23,30 +32,23 @@ func NewSceneLevelsTimeLimit(window *Window) SceneLevelsTimeLimit {
 
 func (scene *SceneLevelsTimeLimit) Init(data any, window *Window) {
 	// init
-	level := newLevel(scene.levelSettings, scene.playerSettings)
-	level.init(window)
-	scene.level = level
+	scene.level = startLevel(scene.levelSettings, scene.playerSettings, data, window)
 }
 
 func (scene *SceneLevelsTimeLimit) GetId() SceneId {
 	return LevelTimeLimit
 }
 
+func (scene *SceneLevelsTimeLimit) GetLevel() *Level {
+	return &scene.level
+}
+
 func (scene *SceneLevelsTimeLimit) HandleUserInput(window *Window) {
 	scene.level.handleUserInput(window)
 }
 
 func (scene *SceneLevelsTimeLimit) Update(window *Window) (SceneId, any) {
-	nextSceneId := scene.GetId()
-	var levelData any = nil
-	if scene.level.status != Stopped {
-		scene.level.update(window)
-		if scene.level.status == Finished {
-			nextSceneId = Transition
-			levelData = &scene.level
-		}
-	}
-	return nextSceneId, levelData
+	return scene.level.updateScene(window)
 }
 
 func (scene *SceneLevelsTimeLimit) Draw(window *Window) {
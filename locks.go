@@ -0,0 +1,74 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// lockStones - a few of the active player's stones sit the turn out, drawn with the level's rng.
+// the locks of the last turn are lifted first, and the player is always left a stone to play
+func (level *Level) lockStones() {
+	for i := range level.stones {
+		level.stones[i].locked = false
+	}
+
+	count := int(level.levelSettings.lockedPerTurn)
+	if count == 0 {
+		return
+	}
+
+	candidates := []int{}
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.playerId == level.playerTurn && stone.canBePlayed() {
+			candidates = append(candidates, i)
+		}
+	}
+
+	count = min(count, len(candidates)-1)
+	if count <= 0 {
+		return
+	}
+
+	level.rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	for _, ix := range candidates[:count] {
+		level.stones[ix].locked = true
+	}
+}
+
+// unlockIfStuck - the stones left unlocked can still be lost before the turn is played, caught outside
+// the closing walls or knocked off. the locks are lifted then, the player would have nothing to play otherwise
+func (level *Level) unlockIfStuck() {
+	locked := []int{}
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.playerId != level.playerTurn || stone.isDead {
+			continue
+		}
+		if stone.canBePlayed() {
+			return
+		}
+		if stone.locked {
+			locked = append(locked, i)
+		}
+	}
+
+	for _, ix := range locked {
+		level.stones[ix].locked = false
+	}
+}
+
+// drawStoneLock - a padlock over the stone, so it reads as locked without the colors
+func drawStoneLock(s *Stone) {
+	if !s.locked {
+		return
+	}
+
+	rl.DrawCircleV(s.pos, s.radius, rl.NewColor(20, 20, 25, 140))
+
+	size := s.radius * 0.5
+	body := rl.NewRectangle(s.pos.X-size*0.6, s.pos.Y-size*0.2, size*1.2, size*0.9)
+	shackle := rl.NewVector2(s.pos.X, body.Y)
+	rl.DrawRing(shackle, size*0.3, size*0.45, 180, 360, 0, dimWhite(220))
+	rl.DrawRectangleRounded(body, 0.3, 4, dimWhite(220))
+	rl.DrawCircleV(rl.NewVector2(s.pos.X, body.Y+body.Height*0.45), size*0.12, rl.NewColor(20, 20, 25, 255))
+}
@@ -15,6 +15,7 @@ var MaxPullLengthAllowed float32
 var MaxPushVelocityAllowed float32
 var StoneRadius float32
 var StoneSelectionCancelCircleRadius float32
+var TouchMinGrabRadius float32
 var FontSize float32
 
 // shards and particles
@@ -23,6 +24,7 @@ var MaxShardRadius float32
 
 // default values
 var IsFullscreen bool = false
+var IsDebug bool = true
 
 // music + audio
 var bgMusic rl.Music
@@ -54,7 +56,7 @@ func NewGame() Game {
 func (g *Game) Init(window *Window) {
 	screenWidth, screenHeight := window.GetScreenDimensions()
 	// magic numbers
-	// ratio is computed based on 2560 x 1440
+	// ratio is computed based on 2560 x 1440, the canvas is that size on every screen
 	VelocityDampingFactor = 0.987
 	VelocityThresholdToStop = screenWidth / 6_000
 	MaxPullLengthAllowed = 0.1 * screenWidth
@@ -63,7 +65,8 @@ func (g *Game) Init(window *Window) {
 	MaxShardRadius = screenWidth / 256
 	StoneRadius = screenHeight * 0.06
 	StoneSelectionCancelCircleRadius = StoneRadius * 0.2
-	FontSize = screenWidth * 0.25
+	TouchMinGrabRadius = StoneRadius * 1.2
+	FontSize = uiFontSize(screenWidth)
 
 	// initialize the gameLevelScene
 	mainScene := NewSceneMain(window)
@@ -78,12 +81,45 @@ func (g *Game) Init(window *Window) {
 	levelTimed := NewSceneLevelsTimeLimit(window)
 	g.scenes[LevelTimeLimit] = &levelTimed
 
+	levelShrinking := NewSceneLevelsShrinking(window)
+	g.scenes[LevelShrinking] = &levelShrinking
+
+	levelPortals := NewSceneLevelsPortals(window)
+	g.scenes[LevelPortals] = &levelPortals
+
+	levelGravity := NewSceneLevelsGravity(window)
+	g.scenes[LevelGravity] = &levelGravity
+
+	levelStoneKinds := NewSceneLevelsStoneKinds(window)
+	g.scenes[LevelStoneKinds] = &levelStoneKinds
+
+	levelPowerUps := NewSceneLevelsPowerUps(window)
+	g.scenes[LevelPowerUps] = &levelPowerUps
+
+	levelSurfaces := NewSceneLevelsSurfaces(window)
+	g.scenes[LevelSurfaces] = &levelSurfaces
+
+	levelPillar := NewSceneLevelsPillar(window)
+	g.scenes[LevelPillar] = &levelPillar
+
+	levelPockets := NewSceneLevelsPockets(window)
+	g.scenes[LevelPockets] = &levelPockets
+
 	gameOverScene := NewSceneTransition()
 	g.scenes[Transition] = &gameOverScene
 
 	optionsScene := NewSceneOptions()
 	g.scenes[Options] = &optionsScene
 
+	achievementsScene := NewSceneAchievements()
+	g.scenes[Achievements] = &achievementsScene
+
+	controlsScene := NewSceneControls()
+	g.scenes[Controls] = &controlsScene
+
+	customizeScene := NewSceneCustomize()
+	g.scenes[Customize] = &customizeScene
+
 	// set the init status
 	g.currentScene = Main
 	g.scenes[g.currentScene].Init(nil, window)
@@ -98,14 +134,34 @@ func (g *Game) Init(window *Window) {
 func (g *Game) Update(window *Window) uint8 {
 	scene := g.scenes[g.currentScene]
 
+	touchInput.update(window)
+
 	scene.HandleUserInput(window)
 
 	nextSceneId, data := scene.Update(window)
 
+	achievements.updateToasts()
+
 	if nextSceneId == Quit {
 		return 1
 	}
 
+	if IsDebug {
+		nextSceneId = debugSceneShortcuts(nextSceneId)
+	}
+
+	if g.currentScene != nextSceneId {
+		// fmt.Printf("Scene change [%d => %d]\n", g.currentScene, nextSceneId)
+		g.saveCurrentMatch(window)
+		g.scenes[nextSceneId].Init(data, window)
+		g.currentScene = nextSceneId
+	}
+
+	return 0
+}
+
+// debugSceneShortcuts - number keys jump straight to the scenes, not available in the release builds
+func debugSceneShortcuts(nextSceneId SceneId) SceneId {
 	if rl.IsKeyDown(rl.KeyZero) {
 		nextSceneId = Main
 	}
@@ -122,21 +178,63 @@ func (g *Game) Update(window *Window) uint8 {
 		nextSceneId = LevelTimeLimit
 	}
 
-	if g.currentScene != nextSceneId {
-		// fmt.Printf("Scene change [%d => %d]\n", g.currentScene, nextSceneId)
-		g.scenes[nextSceneId].Init(data, window)
-		g.currentScene = nextSceneId
+	if rl.IsKeyDown(rl.KeyFour) {
+		nextSceneId = LevelShrinking
 	}
 
-	return 0
+	if rl.IsKeyDown(rl.KeyFive) {
+		nextSceneId = LevelPortals
+	}
+
+	if rl.IsKeyDown(rl.KeySix) {
+		nextSceneId = LevelGravity
+	}
+
+	if rl.IsKeyDown(rl.KeySeven) {
+		nextSceneId = LevelStoneKinds
+	}
+
+	if rl.IsKeyDown(rl.KeyEight) {
+		nextSceneId = LevelPowerUps
+	}
+
+	if rl.IsKeyDown(rl.KeyNine) {
+		nextSceneId = LevelSurfaces
+	}
+
+	if rl.IsKeyDown(rl.KeyMinus) {
+		nextSceneId = LevelPillar
+	}
+
+	if rl.IsKeyDown(rl.KeyEqual) {
+		nextSceneId = LevelPockets
+	}
+
+	return nextSceneId
 }
 
 func (g *Game) Draw(window *Window) {
 	scene := g.scenes[g.currentScene]
 	scene.Draw(window)
+
+	achievements.drawToasts(window)
+}
+
+// saveCurrentMatch - leaving a match that is still going on keeps it for "continue"
+func (g *Game) saveCurrentMatch(window *Window) {
+	levelScene, ok := g.scenes[g.currentScene].(LevelScene)
+	if !ok {
+		return
+	}
+
+	if level := levelScene.GetLevel(); level.canBeSaved() {
+		saveMatch(level, window)
+	}
 }
 
 func (g *Game) Teardown(window *Window) {
+	g.saveCurrentMatch(window)
+
 	g.scenes[Main].Teardown(window)
 	g.scenes[LevelBasic].Teardown(window)
 	g.scenes[LevelBasic].Teardown(window)
@@ -169,7 +267,7 @@ var game = NewGame()
 
 func main() {
 	window := Window{
-		fullscreen:  IsFullscreen,
+		displayMode: Windowed,
 		width:       1920,
 		height:      1080,
 		title:       "flik",
@@ -177,23 +275,33 @@ func main() {
 		sfxVolume:   0.250,
 	}
 
-	rl.SetConfigFlags(rl.FlagMsaa4xHint)
-
-	if window.fullscreen {
-		rl.InitWindow(0, 0, window.title)
-		rl.ToggleFullscreen()
-	} else {
-		rl.InitWindow(window.width, window.height, window.title)
+	if IsFullscreen {
+		window.displayMode = Fullscreen
 	}
+
+	rl.SetConfigFlags(rl.FlagMsaa4xHint | rl.FlagWindowResizable | rl.FlagWindowHighdpi)
+
+	rl.InitWindow(window.width, window.height, window.title)
 	defer rl.CloseWindow()
 
+	rl.SetWindowMinSize(640, 360)
+	window.applyDisplay(window.displayMode, rl.GetCurrentMonitor(), window.width, window.height)
+
 	rl.SetTargetFPS(60)
+	// escape opens the pause menu, the window is closed from the menus
+	rl.SetExitKey(rl.KeyNull)
+	// nothing needs pinching or swiping, a stray pinch shouldn't be read as anything
+	rl.SetGesturesEnabled(uint32(rl.GestureTap | rl.GestureDrag | rl.GestureHold))
 
 	icon := rl.LoadImageFromMemory(".png", iconImage, int32(len(iconImage)))
 	defer rl.UnloadImage(icon)
 
 	rl.SetWindowIcon(*icon)
 
+	window.canvas = rl.LoadRenderTexture(int32(CanvasWidth), int32(CanvasHeight))
+	defer rl.UnloadRenderTexture(window.canvas)
+	rl.SetTextureFilter(window.canvas.Texture, rl.FilterBilinear)
+
 	rl.InitAudioDevice()
 	defer rl.CloseAudioDevice()
 
@@ -219,7 +327,10 @@ func main() {
 	// starts playing the music
 	rl.PlayMusicStream(bgMusic)
 
-	window.maxScreenWidth, window.maxScreenHeight = int32(rl.GetMonitorWidth(rl.GetCurrentMonitor())), int32(rl.GetMonitorHeight(rl.GetCurrentMonitor()))
+	achievements.load()
+	inputBindings.load()
+	config.load()
+	stoneProfiles.load()
 
 	for !rl.WindowShouldClose() {
 		if game.status == GameUninitialized {
@@ -229,14 +340,18 @@ func main() {
 		// loops the music
 		rl.UpdateMusicStream(bgMusic)
 
+		window.follow()
+
 		if game.Update(&window) != 0 {
 			break
 		}
 
-		rl.BeginDrawing()
-
+		rl.BeginTextureMode(window.canvas)
 		game.Draw(&window)
+		rl.EndTextureMode()
 
+		rl.BeginDrawing()
+		window.present()
 		rl.EndDrawing()
 	}
 
@@ -3,6 +3,8 @@
 package main
 
 // init - the whole purpose of this is to make sure that the executable files by default are in fullscreen mode
+// and that the debug shortcuts are not shipped
 func init() {
 	IsFullscreen = true
+	IsDebug = false
 }
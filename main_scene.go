@@ -4,7 +4,7 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-const GAME_INSTRUCTIONS = "> Click & pull back a circle to power up\n> Release to attack\n> Drag back to center to cancel"
+const GAME_INSTRUCTIONS = "> Click or touch & pull back a circle to power up\n> Release to attack\n> Drag back to center to cancel\n> Keyboard aiming: see options > controls"
 
 type buttonRectangle struct {
 	text         string
@@ -18,6 +18,8 @@ type buttonRectangle struct {
 
 type SceneMain struct {
 	nextSceneId      SceneId
+	nextSceneData    any
+	focus            menuFocus
 	logoText         string
 	logoBoundingBox  rl.Rectangle
 	logoFontSize     float32
@@ -44,11 +46,12 @@ func NewSceneMain(window *Window) SceneMain {
 
 	return SceneMain{
 		levelSettings: LevelSettings{
-			sceneId:         LevelBordered,
+			sceneId:         Main,
 			stonesPerPlayer: 1,
 			backgroundColor: BG_COLOR,
 			isBordered:      true,
-			boundary:        bb,
+			arena:           rectangleArena(bb),
+			rules:           defaultRules(),
 		},
 		playerSettings: [TotalPlayerCount]PlayerSettings{
 			PlayerOne: getPlayer("p1", HumanPlayerPalette1, true),
@@ -63,23 +66,26 @@ func (scene *SceneMain) GetId() SceneId {
 
 func (scene *SceneMain) Init(data any, window *Window) {
 	scene.nextSceneId = scene.GetId()
+	scene.nextSceneData = nil
 
 	// initialize the tutorial game
 	level := newLevel(scene.levelSettings, scene.playerSettings)
 	scene.level = level
 	scene.level.status = Initialized
 
-	ww := level.levelSettings.boundary.Width
-	hh := level.levelSettings.boundary.Height
+	frame := level.levelSettings.arena.bounds()
+	ww := frame.Width
+	hh := frame.Height
 
-	playerOneStone := newStone(0, level.levelSettings.boundary.X+ww*0.25, level.levelSettings.boundary.Y+0.75*hh, StoneRadius, 1, PlayerOne)
-	playerTwoStone := newStone(1, level.levelSettings.boundary.X+ww*0.75, level.levelSettings.boundary.Y+0.25*hh, StoneRadius, 1, PlayerTwo)
+	playerOneStone := newStone(0, frame.X+ww*0.25, frame.Y+0.75*hh, StoneRadius, 1, PlayerOne)
+	playerTwoStone := newStone(1, frame.X+ww*0.75, frame.Y+0.25*hh, StoneRadius, 1, PlayerTwo)
 
-	scene.level.stones = []Stone{
+	scene.level.setStones([]Stone{
 		playerOneStone, playerTwoStone,
-	}
+	})
 
 	// the buttons, the text and the logo
+	scene.buttonRectangles = nil
 
 	screenWidth, screenHeight := window.GetScreenDimensions()
 	defaultFont := rl.GetFontDefault()
@@ -93,9 +99,24 @@ func (scene *SceneMain) Init(data any, window *Window) {
 	scene.logoFontSize = FontSize / 2
 	scene.logoBoundingBox = rl.NewRectangle(w, h, measuredSize.X, measuredSize.Y)
 
-	playText := rl.MeasureTextEx(defaultFont, "play", FontSize/5, 10)
 	h = h + measuredSize.Y*1.05 // 5% gap
 
+	if hasSavedMatch() {
+		continueText := rl.MeasureTextEx(defaultFont, "continue", FontSize/5, 10)
+
+		scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
+			text:         "continue",
+			rectangle:    rl.NewRectangle(w, h, continueText.X, continueText.Y),
+			fontSize:     FontSize / 5,
+			targetScene:  Continue,
+			interactable: true,
+		})
+
+		h = h + continueText.Y*1.02 // 2% gap
+	}
+
+	playText := rl.MeasureTextEx(defaultFont, "play", FontSize/5, 10)
+
 	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
 		text:         "play",
 		rectangle:    rl.NewRectangle(w, h, playText.X, playText.Y),
@@ -115,9 +136,20 @@ func (scene *SceneMain) Init(data any, window *Window) {
 		interactable: true,
 	})
 
-	quitText := rl.MeasureTextEx(defaultFont, "quit", FontSize/5, 10)
+	achievementsText := rl.MeasureTextEx(defaultFont, "trophies", FontSize/5, 10)
 	h = h + options.Y*1.02 // 2% gap
 
+	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
+		text:         "trophies",
+		rectangle:    rl.NewRectangle(w, h, achievementsText.X, achievementsText.Y),
+		fontSize:     FontSize / 5,
+		targetScene:  Achievements,
+		interactable: true,
+	})
+
+	quitText := rl.MeasureTextEx(defaultFont, "quit", FontSize/5, 10)
+	h = h + achievementsText.Y*1.02 // 2% gap
+
 	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
 		text:         "quit",
 		rectangle:    rl.NewRectangle(w, h, quitText.X, quitText.Y),
@@ -131,23 +163,23 @@ func (scene *SceneMain) Init(data any, window *Window) {
 	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
 		text: "practice",
 		rectangle: rl.NewRectangle(
-			scene.level.levelSettings.boundary.X+(scene.level.levelSettings.boundary.X-praticeText.X)/2,
-			(scene.level.levelSettings.boundary.Y-praticeText.Y)/2,
+			frame.X+(frame.X-praticeText.X)/2,
+			(frame.Y-praticeText.Y)/2,
 			praticeText.X,
 			praticeText.Y,
 		),
 		fontSize: FontSize / 5,
 	})
 
-	h = (window.GetScreenBoundary().Height - scene.level.levelSettings.boundary.Y - scene.level.levelSettings.boundary.Height)
+	h = (window.GetScreenBoundary().Height - frame.Y - frame.Height)
 
 	instructionsText := rl.MeasureTextEx(rl.GetFontDefault(), GAME_INSTRUCTIONS, FontSize/12, 5)
 
 	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
 		text: GAME_INSTRUCTIONS,
 		rectangle: rl.NewRectangle(
-			scene.level.levelSettings.boundary.X+(scene.level.levelSettings.boundary.X-instructionsText.X)/2,
-			scene.level.levelSettings.boundary.Y+scene.level.levelSettings.boundary.Height+(h-instructionsText.Y)/2,
+			frame.X+(frame.X-instructionsText.X)/2,
+			frame.Y+frame.Height+(h-instructionsText.Y)/2,
 			instructionsText.X,
 			instructionsText.Y,
 		),
@@ -157,9 +189,15 @@ func (scene *SceneMain) Init(data any, window *Window) {
 }
 
 func (scene *SceneMain) HandleUserInput(window *Window) {
-	if rl.IsMouseButtonReleased(rl.MouseButtonLeft) {
-		for _, buttonConfig := range scene.buttonRectangles {
-			if buttonConfig.active {
+	if scene.focus.activated() {
+		for bi, buttonConfig := range scene.buttonRectangles {
+			if !buttonConfig.active {
+				continue
+			}
+
+			if buttonConfig.targetScene == Continue {
+				scene.continueSavedMatch(bi)
+			} else {
 				scene.nextSceneId = buttonConfig.targetScene
 			}
 		}
@@ -171,11 +209,9 @@ func (scene *SceneMain) HandleUserInput(window *Window) {
 func (scene *SceneMain) Update(window *Window) (SceneId, any) {
 	mousePosition := rl.GetMousePosition()
 
-	for bi, buttonConfig := range scene.buttonRectangles {
-		scene.buttonRectangles[bi].active = buttonConfig.interactable && rl.CheckCollisionPointRec(mousePosition, buttonConfig.rectangle)
-	}
+	scene.focus.update(scene.buttonRectangles)
 
-	if rl.CheckCollisionPointRec(mousePosition, scene.level.levelSettings.boundary) {
+	if scene.level.levelSettings.arena.contains(mousePosition) {
 		scene.level.playerSettings[PlayerOne].isCpu = false
 		scene.level.playerSettings[PlayerTwo].isCpu = false
 	}
@@ -186,18 +222,35 @@ func (scene *SceneMain) Update(window *Window) (SceneId, any) {
 		if scene.level.status == Finished {
 			// reinit
 			scene.level.stones[0].isDead = false
-			scene.level.stones[0].life = 100
+			scene.level.stones[0].life = scene.level.levelSettings.rules.startingLife
 
 			scene.level.stones[1].isDead = false
-			scene.level.stones[1].life = 100
+			scene.level.stones[1].life = scene.level.levelSettings.rules.startingLife
 
 			scene.level.score[PlayerOne] = 1
 			scene.level.score[PlayerTwo] = 1
+			scene.level.setStones(scene.level.stones)
 			scene.level.status = Initialized
 		}
 	}
 
-	return scene.nextSceneId, nil
+	return scene.nextSceneId, scene.nextSceneData
+}
+
+// continueSavedMatch - the save stays until the resumed match is finished, given up on or saved over
+func (scene *SceneMain) continueSavedMatch(buttonIx int) {
+	saved, err := loadMatch()
+	if err != nil {
+		rl.TraceLog(rl.LogWarning, "the saved match could not be loaded: %v", err)
+		// it would be offered again on every start otherwise
+		deleteSavedMatch()
+		scene.buttonRectangles[buttonIx].interactable = false
+		scene.buttonRectangles[buttonIx].text = "no save"
+		return
+	}
+
+	scene.nextSceneId = saved.SceneId
+	scene.nextSceneData = saved
 }
 
 func (scene *SceneMain) Draw(window *Window) {
@@ -205,11 +258,7 @@ func (scene *SceneMain) Draw(window *Window) {
 	rl.ClearBackground(BG_COLOR)
 
 	{
-		rl.DrawRectangleLinesEx(
-			scene.level.levelSettings.boundary,
-			scene.level.levelSettings.boundary.Width/255,
-			dimWhite(125),
-		)
+		scene.level.levelSettings.arena.draw(scene.level.levelSettings.arena.bounds().Width/255, dimWhite(125))
 	}
 
 	scene.level.drawObjects()
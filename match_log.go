@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type MatchEventKind = uint8
+
+const (
+	ShotFired     MatchEventKind = iota
+	StoneCollided MatchEventKind = iota
+	WallHit       MatchEventKind = iota
+	StoneDamaged  MatchEventKind = iota
+	StoneDied     MatchEventKind = iota
+	MatchEnded    MatchEventKind = iota
+	StoneSpawned  MatchEventKind = iota // the halves of a splitter
+	StoneHealed   MatchEventKind = iota
+)
+
+// MatchEvent - a single thing that happened during Level.update
+// shot is the index of the shot that was in progress (-1 if none was fired yet)
+type MatchEvent struct {
+	kind     MatchEventKind
+	time     float32
+	shot     int
+	stoneId  uint8
+	playerId Player
+	otherId  uint8   // the other stone for collisions and damage caused by a stone
+	byStone  bool    // whether otherId is meaningful
+	amount   float32 // life removed (damage, death), life added (spawns, heals) or the impact magnitude (collisions, walls)
+}
+
+type MatchLog struct {
+	events      []MatchEvent
+	currentShot int
+	initialLife [TotalPlayerCount]float32
+}
+
+func newMatchLog() MatchLog {
+	return MatchLog{
+		events:      []MatchEvent{},
+		currentShot: -1,
+	}
+}
+
+// shotsFired - currentShot is the index of the last shot, -1 before the first one
+func (log *MatchLog) shotsFired() int {
+	return log.currentShot + 1
+}
+
+func (log *MatchLog) record(level *Level, event MatchEvent) {
+	if event.kind == ShotFired {
+		log.currentShot++
+	}
+	event.time = level.clock.seconds()
+	event.shot = log.currentShot
+	log.events = append(log.events, event)
+
+	level.achievementTracker.observe(level, event)
+}
+
+// damageStone - takes life from the stone and writes it down, a shield soaks up most of the first hit.
+// the recorded amount never goes beyond what the stone actually had left
+func (level *Level) damageStone(s *Stone, amount float32, by *Stone) {
+	if level.levelSettings.rules.indestructible {
+		return
+	}
+
+	amount = breakShield(s, amount*level.levelSettings.rules.damageScale)
+	lost := rl.Clamp(amount, 0, max(s.life, 0))
+	s.life -= amount
+
+	event := MatchEvent{kind: StoneDamaged, stoneId: s.id, playerId: s.playerId, amount: lost}
+	if by != nil {
+		event.otherId = by.id
+		event.byStone = true
+	}
+	level.matchLog.record(level, event)
+}
+
+type ShotSummary struct {
+	index          int
+	shooter        Player
+	stoneId        uint8
+	damageDealt    float32 // enemy life removed
+	damageTaken    float32 // own life removed
+	enemiesKnocked uint8
+	ownKnocked     uint8
+}
+
+type LifeSample struct {
+	time float32
+	life [TotalPlayerCount]float32
+}
+
+type MatchSummary struct {
+	duration  float32
+	shots     []ShotSummary
+	shotCount [TotalPlayerCount]int
+	ownGoals  [TotalPlayerCount]int
+	bestShot  int // index into shots, -1 if nobody managed anything
+	timeline  []LifeSample
+	maxLife   float32
+}
+
+// better - the shot knocking more enemies off wins, otherwise the one dealing more damage
+func (s ShotSummary) better(other ShotSummary) bool {
+	if s.enemiesKnocked != other.enemiesKnocked {
+		return s.enemiesKnocked > other.enemiesKnocked
+	}
+	return s.damageDealt > other.damageDealt
+}
+
+func summarizeMatch(log *MatchLog, duration float32) MatchSummary {
+	summary := MatchSummary{
+		duration: duration,
+		shots:    []ShotSummary{},
+		bestShot: -1,
+	}
+
+	life := log.initialLife
+	summary.maxLife = max(life[PlayerOne], life[PlayerTwo])
+	summary.timeline = append(summary.timeline, LifeSample{time: 0, life: life})
+
+	for _, e := range log.events {
+		if e.kind == ShotFired {
+			summary.shots = append(summary.shots, ShotSummary{
+				index:   len(summary.shots),
+				shooter: e.playerId,
+				stoneId: e.stoneId,
+			})
+			summary.shotCount[e.playerId]++
+			continue
+		}
+
+		if e.kind == StoneSpawned || e.kind == StoneHealed {
+			life[e.playerId] += e.amount
+			summary.maxLife = max(summary.maxLife, life[e.playerId])
+			summary.timeline = append(summary.timeline, LifeSample{time: e.time, life: life})
+			continue
+		}
+
+		if e.kind != StoneDamaged && e.kind != StoneDied {
+			continue
+		}
+
+		life[e.playerId] -= e.amount
+		summary.timeline = append(summary.timeline, LifeSample{time: e.time, life: life})
+
+		if e.shot < 0 || e.shot >= len(summary.shots) {
+			continue
+		}
+
+		shot := &summary.shots[e.shot]
+		ownStone := e.playerId == shot.shooter
+
+		if ownStone {
+			shot.damageTaken += e.amount
+		} else {
+			shot.damageDealt += e.amount
+		}
+
+		if e.kind == StoneDied {
+			if ownStone {
+				shot.ownKnocked++
+				summary.ownGoals[shot.shooter]++
+			} else {
+				shot.enemiesKnocked++
+			}
+		}
+	}
+
+	summary.timeline = append(summary.timeline, LifeSample{time: duration, life: life})
+
+	for i, shot := range summary.shots {
+		if shot.enemiesKnocked == 0 && shot.damageDealt == 0 {
+			continue
+		}
+		if summary.bestShot == -1 || shot.better(summary.shots[summary.bestShot]) {
+			summary.bestShot = i
+		}
+	}
+
+	return summary
+}
+
+func formatDuration(seconds float32) string {
+	total := int(seconds)
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
@@ -10,37 +10,78 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
+type OptionRow = int
+
+// the rows of the options screen, in the order they are drawn
+const (
+	ResolutionRow OptionRow = iota
+	DisplayRow    OptionRow = iota
+	MonitorRow    OptionRow = iota
+	OpponentRow   OptionRow = iota
+	DifficultyRow OptionRow = iota
+	MusicRow      OptionRow = iota
+	SoundRow      OptionRow = iota
+	ColorblindRow OptionRow = iota
+	GlyphsRow     OptionRow = iota
+	ContrastRow   OptionRow = iota
+	MotionRow     OptionRow = iota
+	TextScaleRow  OptionRow = iota
+	SaveRow       OptionRow = iota
+	StonesRow     OptionRow = iota
+	ControlsRow   OptionRow = iota
+	BackRow       OptionRow = iota
+	TotalRowCount OptionRow = iota
+)
+
 type SceneOptions struct {
 	nextSceneId        SceneId
+	returnSceneId      SceneId
+	returnData         any
 	screenSizes        []string
 	screenSizesIx      int32
 	screenSizesEnabled bool
 
-	fullscreen        []string
-	fullscreenIx      int32
-	fullscreenEnabled bool
+	displayModes        []string
+	displayModeIx       int32
+	displayModesEnabled bool
+
+	monitors  []string
+	monitorIx int32
+
+	opponents  []string
+	opponentIx int32
+
+	difficulty Difficulty
 
 	musicVolume float32
 	sfxVolume   float32
 
-	saveClicked bool
-	backClicked bool
+	accessibility AccessibilitySettings
+
+	// gamepad and keyboard navigation
+	focusRow     OptionRow
+	focusVisible bool
+
+	saveClicked     bool
+	stonesClicked   bool
+	controlsClicked bool
+	backClicked     bool
 }
 
 func NewSceneOptions() SceneOptions {
 
 	return SceneOptions{
-		screenSizes: []string{
-			" 1280x720",
-			" 1440x810",
-			" 1920x1080",
-		},
 		screenSizesEnabled: false,
-		fullscreen: []string{
-			" no",
-			" yes",
+		displayModes: []string{
+			Windowed:           " windowed",
+			BorderlessWindowed: " borderless",
+			Fullscreen:         " fullscreen",
+		},
+		displayModesEnabled: false,
+		opponents: []string{
+			"cpu",
+			"local player",
 		},
-		fullscreenEnabled: false,
 	}
 }
 
@@ -50,67 +91,230 @@ func (scene *SceneOptions) GetId() SceneId {
 
 func (scene *SceneOptions) Init(data any, window *Window) {
 	scene.nextSceneId = scene.GetId()
-	fullscreenIx := int32(0) // no
-	if window.fullscreen {
-		fullscreenIx = 1 // yes
+
+	// opened from the pause menu, "back" returns to the paused level
+	scene.returnSceneId = Main
+	scene.returnData = nil
+	if level, ok := data.(*Level); ok {
+		scene.returnSceneId = level.levelSettings.sceneId
+		scene.returnData = level
 	}
-	scene.fullscreenIx = fullscreenIx
+	scene.displayModeIx = int32(window.displayMode)
 
-	w, h := window.GetScreenDimensions()
+	scene.monitors = nil
+	for monitor := range rl.GetMonitorCount() {
+		scene.monitors = append(scene.monitors, fmt.Sprintf("%d: %s", monitor+1, rl.GetMonitorName(monitor)))
+	}
+	scene.monitorIx = int32(window.monitor)
 
-	current := fmt.Sprintf(" %dx%d", int(w), int(h))
+	scene.updateScreenSizes(window.width, window.height)
 
-	ix := slices.Index(scene.screenSizes, current)
+	scene.musicVolume = window.musicVolume
+	scene.sfxVolume = window.sfxVolume
 
-	if ix == -1 {
-		scene.screenSizesIx = int32(len(scene.screenSizes))
-		scene.screenSizes = append(scene.screenSizes, current)
-	} else {
-		scene.screenSizesIx = int32(ix)
+	scene.accessibility = config.Accessibility
+	scene.difficulty = config.Difficulty
+
+	scene.opponentIx = 0
+	if config.HotSeat {
+		scene.opponentIx = 1
 	}
 
-	maxSize := fmt.Sprintf(" %dx%d", window.maxScreenWidth, window.maxScreenHeight)
+	scene.focusVisible = false
 
-	if !slices.Contains(scene.screenSizes, maxSize) {
-		scene.screenSizes = append(scene.screenSizes, maxSize)
+	// the buttons are remembered from the last visit otherwise
+	scene.saveClicked = false
+	scene.stonesClicked = false
+	scene.controlsClicked = false
+	scene.backClicked = false
+}
+
+func (scene *SceneOptions) HandleUserInput(window *Window) {
+	if menuBackPressed() {
+		scene.backClicked = true
+		return
 	}
 
-	scene.musicVolume = window.musicVolume
-	scene.sfxVolume = window.sfxVolume
+	step := 0
+	if menuUpPressed() {
+		step = -1
+	}
+	if menuDownPressed() {
+		step = 1
+	}
+
+	if step != 0 {
+		if scene.focusVisible {
+			scene.focusRow = (scene.focusRow + step + TotalRowCount) % TotalRowCount
+		} else {
+			scene.focusRow = 0
+		}
+		scene.focusVisible = true
+		// the dropdowns would cover the rows below them
+		scene.screenSizesEnabled = false
+		scene.displayModesEnabled = false
+	}
+
+	if rl.Vector2Length(rl.GetMouseDelta()) > 0 {
+		scene.focusVisible = false
+	}
+
+	if !scene.focusVisible {
+		return
+	}
+
+	if menuLeftPressed() {
+		scene.adjust(-1)
+	}
+
+	if menuRightPressed() {
+		scene.adjust(1)
+	}
+
+	if menuConfirmPressed() {
+		switch scene.focusRow {
+		case SaveRow:
+			scene.saveClicked = true
+		case StonesRow:
+			scene.stonesClicked = true
+		case ControlsRow:
+			scene.controlsClicked = true
+		case BackRow:
+			scene.backClicked = true
+		default:
+			scene.adjust(1)
+		}
+	}
 }
 
-func (scene *SceneOptions) HandleUserInput(window *Window) {
+// commonScreenSizes - offered when they fit on the monitor
+var commonScreenSizes = [][2]int32{
+	{1280, 720},
+	{1440, 810},
+	{1600, 900},
+	{1920, 1080},
+	{2560, 1440},
+	{3840, 2160},
+}
+
+// updateScreenSizes - the window sizes that fit on the picked monitor, plus the monitor's own size.
+// the given size stays selected, it's added when it's not one of the usual ones
+func (scene *SceneOptions) updateScreenSizes(width, height int32) {
+	monitorWidth, monitorHeight := monitorSize(int(scene.monitorIx))
+
+	scene.screenSizes = nil
+	for _, size := range commonScreenSizes {
+		if size[0] <= monitorWidth && size[1] <= monitorHeight {
+			scene.screenSizes = append(scene.screenSizes, fmt.Sprintf(" %dx%d", size[0], size[1]))
+		}
+	}
 
+	current := fmt.Sprintf(" %dx%d", width, height)
+	for _, size := range []string{current, fmt.Sprintf(" %dx%d", monitorWidth, monitorHeight)} {
+		if !slices.Contains(scene.screenSizes, size) {
+			scene.screenSizes = append(scene.screenSizes, size)
+		}
+	}
+
+	scene.screenSizesIx = int32(slices.Index(scene.screenSizes, current))
 }
 
-func (scene *SceneOptions) Update(window *Window) (SceneId, any) {
-	if scene.saveClicked {
-		fullscreen := strings.Trim(scene.fullscreen[scene.fullscreenIx], " ")
-		shouldBeFullscreen := fullscreen == "yes"
-		if shouldBeFullscreen {
-			rl.SetWindowState(rl.FlagFullscreenMode)
-		} else {
-			rl.ClearWindowState(rl.FlagFullscreenMode)
+// selectedScreenSize - the size picked in the resolution row
+func (scene *SceneOptions) selectedScreenSize() (int32, int32) {
+	pieces := strings.Split(strings.Trim(scene.screenSizes[scene.screenSizesIx], " "), "x")
+	width, _ := strconv.Atoi(pieces[0])
+	height, _ := strconv.Atoi(pieces[1])
+	return int32(width), int32(height)
+}
+
+// pickMonitor - the resolutions are the ones of the monitor, so they change with it
+func (scene *SceneOptions) pickMonitor(monitorIx int32) {
+	if monitorIx == scene.monitorIx {
+		return
+	}
+	width, height := scene.selectedScreenSize()
+	scene.monitorIx = monitorIx
+	scene.updateScreenSizes(width, height)
+}
+
+// adjust - changes the value of the focused row, the lists wrap around
+func (scene *SceneOptions) adjust(direction int) {
+	cycle := func(ix int32, count int) int32 {
+		if count == 0 {
+			return ix
 		}
-		window.fullscreen = shouldBeFullscreen
+		return int32((int(ix) + direction + count) % count)
+	}
 
-		pieces := strings.Split(strings.Trim(scene.screenSizes[scene.screenSizesIx], " "), "x")
-		newWidth, _ := strconv.Atoi(pieces[0])
-		newHeight, _ := strconv.Atoi(pieces[1])
-		rl.SetWindowSize(newWidth, newHeight)
-		rl.SetWindowPosition(100, 100)
-		window.width = int32(newWidth)
-		window.height = int32(newHeight)
+	switch scene.focusRow {
+	case ResolutionRow:
+		scene.screenSizesIx = cycle(scene.screenSizesIx, len(scene.screenSizes))
+	case DisplayRow:
+		scene.displayModeIx = cycle(scene.displayModeIx, len(scene.displayModes))
+	case MonitorRow:
+		scene.pickMonitor(cycle(scene.monitorIx, len(scene.monitors)))
+	case OpponentRow:
+		scene.opponentIx = cycle(scene.opponentIx, len(scene.opponents))
+	case DifficultyRow:
+		scene.difficulty = Difficulty(cycle(int32(scene.difficulty), int(TotalDifficulties)))
+	case MusicRow:
+		scene.musicVolume = rl.Clamp(scene.musicVolume+float32(direction)*0.05, 0, 1)
+	case SoundRow:
+		scene.sfxVolume = rl.Clamp(scene.sfxVolume+float32(direction)*0.05, 0, 1)
+	case ColorblindRow:
+		scene.accessibility.ColorblindPreset = ColorblindPreset(cycle(int32(scene.accessibility.ColorblindPreset), int(TotalColorblindPresets)))
+	case GlyphsRow:
+		scene.accessibility.PlayerGlyphs = !scene.accessibility.PlayerGlyphs
+	case ContrastRow:
+		scene.accessibility.HighContrast = !scene.accessibility.HighContrast
+	case MotionRow:
+		scene.accessibility.ReduceMotion = !scene.accessibility.ReduceMotion
+	case TextScaleRow:
+		scene.accessibility.TextScale = rl.Clamp(scene.accessibility.TextScale+float32(direction)*0.05, minTextScale, maxTextScale)
+	}
+}
+
+// focusState - the raygui state a row is drawn with, so the focused one looks hovered
+func (scene *SceneOptions) focusState(row OptionRow) {
+	if scene.focusVisible && scene.focusRow == row {
+		gui.SetState(gui.STATE_FOCUSED)
+	} else {
+		gui.SetState(gui.STATE_NORMAL)
+	}
+}
+
+func (scene *SceneOptions) Update(window *Window) (SceneId, any) {
+	if scene.saveClicked {
+		newWidth, newHeight := scene.selectedScreenSize()
+		window.applyDisplay(DisplayMode(scene.displayModeIx), int(scene.monitorIx), newWidth, newHeight)
 
 		//
 		window.musicVolume = scene.musicVolume
 		window.sfxVolume = scene.sfxVolume
 
-		game.status = GameUninitialized
+		rl.SetMusicVolume(bgMusic, window.musicVolume)
+
+		config.HotSeat = scene.opponentIx == 1
+		config.Difficulty = scene.difficulty
+		config.Accessibility = scene.accessibility
+		config.save()
+
+		FontSize = uiFontSize(CanvasWidth)
+
+		// the canvas doesn't change with the window, so the game carries on as it was
+		return scene.returnSceneId, scene.returnData
+	}
+
+	if scene.stonesClicked {
+		return Customize, scene.returnData
+	}
+
+	if scene.controlsClicked {
+		return Controls, scene.returnData
 	}
 
 	if scene.backClicked {
-		return Main, nil
+		return scene.returnSceneId, scene.returnData
 	}
 
 	return scene.nextSceneId, nil
@@ -122,102 +326,183 @@ func (scene *SceneOptions) Draw(window *Window) {
 
 	ScreenWidth, ScreenHeight := window.GetScreenDimensions()
 
-	gui.SetStyle(gui.DEFAULT, gui.BACKGROUND_COLOR, colorToInt64(BG_COLOR))
-	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_NORMAL, colorToInt64(BG_COLOR))
-	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_FOCUSED, colorToInt64(BG_COLOR))
-	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_PRESSED, colorToInt64(dimWhite(120)))
-
-	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_NORMAL, colorToInt64(BG_COLOR))
-	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_FOCUSED, colorToInt64(dimWhite(200)))
-	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_PRESSED, colorToInt64(dimWhite(255)))
+	drawGuiTitle(window, "OPTIONS")
 
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_FOCUSED, colorToInt64(dimWhite(200)))
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_PRESSED, colorToInt64(dimWhite(255)))
+	rowHeight := ScreenHeight / 20
 
-	gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
-	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_CENTER))
+	// the display and the sound on the left, the accessibility on the right
+	label := func(column float32, yAxis float32, text string) {
+		gui.SetState(gui.STATE_NORMAL)
+		gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
+		gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
+		gui.Label(rl.NewRectangle(column, yAxis, ScreenWidth*0.23, rowHeight), text)
+	}
+	control := func(column float32, yAxis float32) rl.Rectangle {
+		return rl.NewRectangle(column+ScreenWidth*0.25, yAxis, ScreenWidth*0.2, rowHeight)
+	}
+	toggle := func(on bool) int32 {
+		if on {
+			return 1
+		}
+		return 0
+	}
 
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_SIZE, int64(FontSize/3))
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_SPACING, int64(FontSize/60))
-	gui.Label(rl.NewRectangle(0, ScreenHeight*0.125, ScreenWidth, ScreenHeight/5), "OPTIONS")
+	left := ScreenWidth * 0.04
+	right := ScreenWidth * 0.5
 
-	// reset it back to the original
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_SIZE, int64(FontSize/10))
-	gui.SetStyle(gui.DEFAULT, gui.TEXT_SPACING, int64(FontSize/200))
+	// nothing is drawn under an open dropdown
+	dropdownOpen := scene.screenSizesEnabled || scene.displayModesEnabled
 
 	yAxis := ScreenHeight / 3
 	gui.SetStyle(gui.DROPDOWNBOX, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_LEFT))
-	x := strings.Join(scene.screenSizes, ";")
-	if gui.DropdownBox(rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, ScreenHeight/20), x, &scene.screenSizesIx, scene.screenSizesEnabled) {
+	scene.focusState(ResolutionRow)
+	if gui.DropdownBox(control(left, yAxis), strings.Join(scene.screenSizes, ";"), &scene.screenSizesIx, scene.screenSizesEnabled) {
 		scene.screenSizesEnabled = !scene.screenSizesEnabled
 	}
+	label(left, yAxis, "resolution")
 
-	gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
-	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
-	gui.Label(rl.NewRectangle(0, yAxis, ScreenWidth*0.45, ScreenHeight/20), "resolution")
-
-	yAxis += ScreenHeight / 20
-
-	gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
-	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
-	gui.Label(rl.NewRectangle(0, yAxis, ScreenWidth*0.45, ScreenHeight/20), "fullscreen")
+	yAxis += rowHeight
 
+	label(left, yAxis, "display")
 	if !scene.screenSizesEnabled {
 		gui.SetStyle(gui.DROPDOWNBOX, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_LEFT))
-		x = strings.Join(scene.fullscreen, ";")
-		if gui.DropdownBox(rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, ScreenHeight/20), x, &scene.fullscreenIx, scene.fullscreenEnabled) {
-			scene.fullscreenEnabled = !scene.fullscreenEnabled
+		scene.focusState(DisplayRow)
+		if gui.DropdownBox(control(left, yAxis), strings.Join(scene.displayModes, ";"), &scene.displayModeIx, scene.displayModesEnabled) {
+			scene.displayModesEnabled = !scene.displayModesEnabled
 		}
 	}
 
-	yAxis += ScreenHeight / 20
+	yAxis += rowHeight
 
-	gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
-	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
-	gui.Label(rl.NewRectangle(0, yAxis, ScreenWidth*0.45, ScreenHeight/20), "music")
+	label(left, yAxis, "monitor")
+	if !dropdownOpen {
+		scene.focusState(MonitorRow)
+		scene.pickMonitor(gui.ComboBox(control(left, yAxis), strings.Join(scene.monitors, ";"), scene.monitorIx))
+	}
+
+	yAxis += rowHeight
 
-	if !scene.screenSizesEnabled && !scene.fullscreenEnabled {
-		scene.musicVolume = gui.SliderBar(
-			rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, ScreenHeight/20),
-			"",
-			"",
-			scene.musicVolume,
-			0,
-			1,
-		)
+	label(left, yAxis, "opponent")
+	if !dropdownOpen {
+		scene.focusState(OpponentRow)
+		scene.opponentIx = gui.ComboBox(control(left, yAxis), strings.Join(scene.opponents, ";"), scene.opponentIx)
 	}
 
-	yAxis += ScreenHeight / 20
+	yAxis += rowHeight
 
-	gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
-	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_RIGHT))
-	gui.Label(rl.NewRectangle(0, yAxis, ScreenWidth*0.45, ScreenHeight/20), "sound")
+	label(left, yAxis, "difficulty")
+	if !dropdownOpen {
+		scene.focusState(DifficultyRow)
+		scene.difficulty = Difficulty(gui.ComboBox(control(left, yAxis), strings.Join(DifficultyNames[:], ";"), int32(scene.difficulty)))
+	}
 
-	if !scene.screenSizesEnabled && !scene.fullscreenEnabled {
-		scene.sfxVolume = gui.SliderBar(
-			rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, ScreenHeight/20),
-			"",
-			"",
-			scene.sfxVolume,
-			0,
-			1,
-		)
+	yAxis += rowHeight
+
+	label(left, yAxis, "music")
+	if !dropdownOpen {
+		scene.focusState(MusicRow)
+		scene.musicVolume = gui.SliderBar(control(left, yAxis), "", "", scene.musicVolume, 0, 1)
 	}
 
-	yAxis += ScreenHeight / 5
+	yAxis += rowHeight
 
-	scene.saveClicked = gui.Button(
-		rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, ScreenHeight/20),
-		"save",
-	)
+	label(left, yAxis, "sound")
+	if !dropdownOpen {
+		scene.focusState(SoundRow)
+		scene.sfxVolume = gui.SliderBar(control(left, yAxis), "", "", scene.sfxVolume, 0, 1)
+	}
+
+	yAxis = ScreenHeight / 3
+
+	label(right, yAxis, "colorblind")
+	scene.focusState(ColorblindRow)
+	scene.accessibility.ColorblindPreset = ColorblindPreset(gui.ComboBox(
+		control(right, yAxis),
+		strings.Join(ColorblindPresetNames[:], ";"),
+		int32(scene.accessibility.ColorblindPreset),
+	))
+
+	yAxis += rowHeight
+
+	label(right, yAxis, "player shapes")
+	scene.focusState(GlyphsRow)
+	scene.accessibility.PlayerGlyphs = gui.ComboBox(control(right, yAxis), "off;on", toggle(scene.accessibility.PlayerGlyphs)) == 1
+
+	yAxis += rowHeight
+
+	label(right, yAxis, "high contrast")
+	scene.focusState(ContrastRow)
+	scene.accessibility.HighContrast = gui.ComboBox(control(right, yAxis), "off;on", toggle(scene.accessibility.HighContrast)) == 1
 
-	yAxis += ScreenHeight / 20
+	yAxis += rowHeight
 
-	scene.backClicked = gui.Button(
-		rl.NewRectangle(ScreenWidth/2, yAxis, ScreenWidth*0.25, ScreenHeight/20),
-		"back",
+	label(right, yAxis, "reduce motion")
+	scene.focusState(MotionRow)
+	scene.accessibility.ReduceMotion = gui.ComboBox(control(right, yAxis), "off;on", toggle(scene.accessibility.ReduceMotion)) == 1
+
+	yAxis += rowHeight
+
+	label(right, yAxis, "text size")
+	scene.focusState(TextScaleRow)
+	scene.accessibility.TextScale = gui.SliderBar(
+		control(right, yAxis),
+		"",
+		fmt.Sprintf("%d%%", int(scene.accessibility.TextScale*100+0.5)),
+		scene.accessibility.TextScale,
+		minTextScale,
+		maxTextScale,
 	)
+
+	yAxis = ScreenHeight/3 + rowHeight*8
+
+	scene.focusState(SaveRow)
+	scene.saveClicked = scene.saveClicked || gui.Button(control(left, yAxis), "save")
+
+	yAxis += rowHeight
+
+	scene.focusState(StonesRow)
+	scene.stonesClicked = scene.stonesClicked || gui.Button(control(left, yAxis), "stones")
+
+	yAxis += rowHeight
+
+	scene.focusState(ControlsRow)
+	scene.controlsClicked = scene.controlsClicked || gui.Button(control(left, yAxis), "controls")
+
+	yAxis += rowHeight
+
+	scene.focusState(BackRow)
+	scene.backClicked = scene.backClicked || gui.Button(control(left, yAxis), "back")
+
+	gui.SetState(gui.STATE_NORMAL)
+}
+
+// drawGuiTitle - sets up the raygui look shared by the settings screens and draws the title
+func drawGuiTitle(window *Window, title string) {
+	ScreenWidth, ScreenHeight := window.GetScreenDimensions()
+
+	gui.SetStyle(gui.DEFAULT, gui.BACKGROUND_COLOR, colorToInt64(BG_COLOR))
+	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_NORMAL, colorToInt64(BG_COLOR))
+	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_FOCUSED, colorToInt64(BG_COLOR))
+	gui.SetStyle(gui.DEFAULT, gui.BASE_COLOR_PRESSED, colorToInt64(dimWhite(120)))
+
+	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_NORMAL, colorToInt64(BG_COLOR))
+	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_FOCUSED, colorToInt64(dimWhite(200)))
+	gui.SetStyle(gui.DEFAULT, gui.BORDER_COLOR_PRESSED, colorToInt64(dimWhite(255)))
+
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_FOCUSED, colorToInt64(dimWhite(200)))
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_COLOR_PRESSED, colorToInt64(dimWhite(255)))
+
+	gui.SetStyle(gui.LABEL, gui.TEXT_COLOR_NORMAL, colorToInt64(dimWhite(120)))
+	gui.SetStyle(gui.LABEL, gui.TEXT_ALIGNMENT, int64(gui.TEXT_ALIGN_CENTER))
+
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_SIZE, int64(FontSize/3))
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_SPACING, int64(FontSize/60))
+	gui.Label(rl.NewRectangle(0, ScreenHeight*0.125, ScreenWidth, ScreenHeight/5), title)
+
+	// reset it back to the original
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_SIZE, int64(FontSize/10))
+	gui.SetStyle(gui.DEFAULT, gui.TEXT_SPACING, int64(FontSize/200))
 }
 
 func (scene *SceneOptions) Teardown(window *Window) {
@@ -0,0 +1,206 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type PauseAction = uint8
+
+const (
+	NoPauseAction PauseAction = iota
+	Resume        PauseAction = iota
+	Restart       PauseAction = iota
+	OpenOptions   PauseAction = iota
+	Forfeit       PauseAction = iota
+	BackToMenu    PauseAction = iota
+)
+
+type PauseMenu struct {
+	buttons []buttonRectangle
+	actions []PauseAction // the action of each button
+	focus   menuFocus
+	chosen  PauseAction
+}
+
+func newPauseMenu(window *Window) PauseMenu {
+	screenWidth, screenHeight := window.GetScreenDimensions()
+	defaultFont := rl.GetFontDefault()
+
+	menu := PauseMenu{}
+
+	entries := []struct {
+		text   string
+		action PauseAction
+	}{
+		{"resume", Resume},
+		{"restart", Restart},
+		{"options", OpenOptions},
+		{"forfeit", Forfeit},
+		{"main menu", BackToMenu},
+	}
+
+	h := screenHeight * 0.38
+	for _, entry := range entries {
+		measured := rl.MeasureTextEx(defaultFont, entry.text, FontSize/7, 10)
+		menu.buttons = append(menu.buttons, buttonRectangle{
+			text:         entry.text,
+			rectangle:    rl.NewRectangle((screenWidth-measured.X)/2, h, measured.X, measured.Y),
+			fontSize:     FontSize / 7,
+			interactable: true,
+		})
+		menu.actions = append(menu.actions, entry.action)
+		h += measured.Y * 1.2
+	}
+
+	return menu
+}
+
+// layout - the pause menu is placed when the level is paused, and again when it comes back from the options
+// since the text size may have changed there. the canvas itself never changes size
+func (level *Level) layout(window *Window) {
+	if level.status != Stopped {
+		return
+	}
+	focus := level.pauseMenu.focus
+	level.pauseMenu = newPauseMenu(window)
+	level.pauseMenu.focus = focus
+}
+
+func (menu *PauseMenu) handleUserInput() {
+	menu.focus.update(menu.buttons)
+
+	if menuBackPressed() {
+		menu.chosen = Resume
+		return
+	}
+
+	if menu.focus.activated() {
+		for bi, button := range menu.buttons {
+			if button.active {
+				menu.chosen = menu.actions[bi]
+			}
+		}
+	}
+}
+
+func (menu *PauseMenu) draw(window *Window) {
+	screenWidth, screenHeight := window.GetScreenDimensions()
+	defaultFont := rl.GetFontDefault()
+
+	rl.DrawRectangleV(rl.NewVector2(0, 0), rl.NewVector2(screenWidth, screenHeight), rl.ColorAlpha(BG_COLOR, 0.85))
+
+	title := "paused"
+	measured := rl.MeasureTextEx(defaultFont, title, FontSize/4, 10)
+	rl.DrawTextEx(defaultFont, title, rl.NewVector2((screenWidth-measured.X)/2, screenHeight*0.18), FontSize/4, 10, dimWhite(120))
+
+	for _, button := range menu.buttons {
+		dimLevel := uint8(60)
+		if button.active {
+			dimLevel = 255
+		}
+
+		rl.DrawTextEx(
+			defaultFont,
+			button.text,
+			rl.NewVector2(button.rectangle.X, button.rectangle.Y),
+			button.fontSize,
+			10,
+			dimWhite(dimLevel),
+		)
+	}
+}
+
+// pause - freezes the simulation, the timers and the audio
+func (level *Level) pause(window *Window) {
+	if level.status != Initialized {
+		return
+	}
+
+	// an unfinished aim is dropped, the button might be released while the menu is open
+	if level.action == StoneAimed {
+		level.cancelAim()
+	}
+
+	level.status = Stopped
+	level.pauseMenu = newPauseMenu(window)
+	level.clock.pause()
+
+	rl.PauseMusicStream(bgMusic)
+	rl.PauseSound(stoneExplosionSfx)
+	rl.PauseSound(stoneToWallImpactSfx)
+	rl.PauseSound(stoneToStoneImpactSfx)
+}
+
+func (level *Level) resume() {
+	if level.status != Stopped {
+		return
+	}
+
+	level.status = Initialized
+	level.pauseMenu.chosen = NoPauseAction
+	level.clock.resume()
+
+	rl.ResumeMusicStream(bgMusic)
+	rl.ResumeSound(stoneExplosionSfx)
+	rl.ResumeSound(stoneToWallImpactSfx)
+	rl.ResumeSound(stoneToStoneImpactSfx)
+}
+
+// forfeit - the human player gives up; in a hot-seat match it's the one whose turn it is
+func (level *Level) forfeit() {
+	loser := level.playerTurn
+	for p := range TotalPlayerCount {
+		if level.playerSettings[p].isCpu {
+			loser = TotalPlayerCount - 1 - p
+		}
+	}
+
+	level.score[loser] = 0
+	level.finishReason = FinishedByForfeit
+	level.status = Finished
+	level.playerTurn = PlayerOne
+	level.matchLog.record(level, MatchEvent{kind: MatchEnded, playerId: level.winner()})
+}
+
+// updateScene - the part of the Update shared by all the level scenes
+func (level *Level) updateScene(window *Window) (SceneId, any) {
+	sceneId := level.levelSettings.sceneId
+
+	if level.status == Stopped {
+		chosen := level.pauseMenu.chosen
+		level.pauseMenu.chosen = NoPauseAction
+
+		switch chosen {
+		case Resume:
+			level.resume()
+		case Restart:
+			// the save of the match given up on would come back with "continue" otherwise
+			level.dropSave()
+			level.resume()
+			*level = startLevel(level.levelSettings, level.playerSettings, nil, window)
+		case OpenOptions:
+			// the level stays paused, the options scene hands it back on its way out
+			return Options, level
+		case Forfeit:
+			level.resume()
+			level.forfeit()
+			level.dropSave()
+			return Transition, level
+		case BackToMenu:
+			// leaving the level keeps the match for "continue"
+			level.resume()
+			return Main, nil
+		}
+
+		return sceneId, nil
+	}
+
+	level.update(window)
+	if level.status == Finished { // TODO: this needs to be elaborate - is it a win, is it a loss?
+		// a decided match can't be continued, the save left from opening the options would still offer it
+		level.dropSave()
+		return Transition, level
+	}
+
+	return sceneId, nil
+}
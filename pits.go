@@ -0,0 +1,170 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Pit - a hole in the field, like the pockets of a pool table. a stone whose center gets over it falls in
+type Pit struct {
+	pos    rl.Vector2
+	radius float32
+}
+
+// Gate - a one-way wall, the stones going to the left of from→to pass it and the others bounce off
+type Gate struct {
+	from, to rl.Vector2
+}
+
+// passDirection - the way the stones go through
+func (gate Gate) passDirection() rl.Vector2 {
+	along := rl.Vector2Subtract(gate.to, gate.from)
+	return rl.Vector2Normalize(rl.NewVector2(along.Y, -along.X))
+}
+
+// contact - only a stone on the blocking side going into the gate touches it,
+// so the ones going through aren't pushed back while they cross it
+func (gate Gate) contact(pos, velocity rl.Vector2, radius float32) (WallContact, bool) {
+	pass := gate.passDirection()
+	if rl.Vector2DotProduct(rl.Vector2Subtract(pos, gate.from), pass) <= 0 || rl.Vector2DotProduct(velocity, pass) >= 0 {
+		return WallContact{}, false
+	}
+
+	closest := closestOnSegment(pos, gate.from, gate.to)
+	away := rl.Vector2Subtract(pos, closest)
+	distance := rl.Vector2Length(away)
+	if distance >= radius || distance == 0 {
+		return WallContact{}, false
+	}
+
+	return WallContact{point: closest, normal: rl.Vector2Scale(away, 1/distance), depth: radius - distance}, true
+}
+
+// wallContacts - the walls of the arena and the gates the stone is up against
+func (level *Level) wallContacts(s *Stone) []WallContact {
+	contacts := []WallContact{}
+	if level.levelSettings.isBordered {
+		contacts = level.levelSettings.arena.wallContacts(s.pos, s.radius)
+	}
+
+	for _, gate := range level.levelSettings.gates {
+		if contact, ok := gate.contact(s.pos, s.velocity, s.radius); ok {
+			contacts = append(contacts, contact)
+		}
+	}
+
+	return contacts
+}
+
+// rayToWalls - the distance along the ray until a circle of the radius touches the walls or a gate it can't pass,
+// and the normal it bounces off of. it's infinite when there's nothing to bounce off of
+func (level *Level) rayToWalls(origin, direction rl.Vector2, radius float32) (float32, rl.Vector2) {
+	bestT := float32(math.Inf(1))
+	bestNormal := rl.NewVector2(0, 0)
+
+	if level.levelSettings.isBordered {
+		if t, normal, ok := level.levelSettings.arena.rayCast(origin, direction, radius); ok {
+			bestT, bestNormal = t, normal
+		}
+	}
+
+	for _, gate := range level.levelSettings.gates {
+		pass := gate.passDirection()
+		if rl.Vector2DotProduct(rl.Vector2Subtract(origin, gate.from), pass) <= 0 || rl.Vector2DotProduct(direction, pass) >= 0 {
+			continue
+		}
+
+		if t, normal, ok := rayToEdges(polygonArena(gate.from, gate.to), origin, direction, radius); ok && t < bestT {
+			bestT, bestNormal = t, normal
+		}
+	}
+
+	return bestT, bestNormal
+}
+
+// fellInPit - the center of the stone is over one of the pits
+func (level *Level) fellInPit(s *Stone) bool {
+	for _, pit := range level.levelSettings.pits {
+		if rl.Vector2Distance(s.pos, pit.pos) <= pit.radius {
+			return true
+		}
+	}
+	return false
+}
+
+// rayToRingOut - the distance along the ray until the stone is lost, over a pit or out of the field.
+// bordered levels are only left through their open sides
+func (level *Level) rayToRingOut(origin, direction rl.Vector2) float32 {
+	bestT := float32(math.Inf(1))
+
+	for _, pit := range level.levelSettings.pits {
+		if t, ok := rayToCircle(origin, direction, pit.pos, pit.radius); ok {
+			bestT = min(bestT, t)
+		}
+	}
+
+	if level.levelSettings.isBordered {
+		if t, ok := level.levelSettings.arena.rayToOpenSide(origin, direction); ok {
+			bestT = min(bestT, t)
+		}
+	} else if t, _, ok := level.playArea().rayCast(origin, direction, 0); ok {
+		bestT = min(bestT, t)
+	}
+
+	return bestT
+}
+
+// pitBehind - whether a stone pushed from the point along the direction would likely end up lost, for the cpu
+func (level *Level) pitBehind(from, direction rl.Vector2) bool {
+	return level.rayToRingOut(from, rl.Vector2Normalize(direction)) < StoneRadius*4
+}
+
+// pathCrossesPit - whether a stone going from one point to the other would fall in on the way
+func (level *Level) pathCrossesPit(from, to rl.Vector2) bool {
+	for _, pit := range level.levelSettings.pits {
+		if rl.CheckCollisionCircleLine(pit.pos, pit.radius, from, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func (level *Level) drawPits() {
+	for _, pit := range level.levelSettings.pits {
+		rl.DrawCircleV(pit.pos, pit.radius, rl.NewColor(0, 0, 0, 150))
+		rl.DrawRing(pit.pos, pit.radius*0.92, pit.radius, 0, 360, 0, dimWhite(60))
+	}
+}
+
+// drawGates - a line with arrows showing the way through
+func (level *Level) drawGates(lineColor rl.Color) {
+	for _, gate := range level.levelSettings.gates {
+		rl.DrawLineEx(gate.from, gate.to, StoneRadius*0.16, lineColor)
+
+		pass := gate.passDirection()
+		side := rl.Vector2Normalize(rl.Vector2Subtract(gate.to, gate.from))
+		size := StoneRadius * 0.25
+		length := rl.Vector2Distance(gate.from, gate.to)
+
+		for d := size * 2; d < length-size; d += size * 3 {
+			tip := rl.Vector2Add(rl.Vector2Add(gate.from, rl.Vector2Scale(side, d)), rl.Vector2Scale(pass, size*1.5))
+			back := rl.Vector2Subtract(tip, rl.Vector2Scale(pass, size))
+			rl.DrawLineEx(tip, rl.Vector2Add(back, rl.Vector2Scale(side, size)), StoneRadius*0.05, lineColor)
+			rl.DrawLineEx(tip, rl.Vector2Subtract(back, rl.Vector2Scale(side, size)), StoneRadius*0.05, lineColor)
+		}
+	}
+}
+
+// drawDashedLine - the open sides of an arena
+func drawDashedLine(from, to rl.Vector2, thickness float32, color rl.Color) {
+	length := rl.Vector2Distance(from, to)
+	direction := rl.Vector2Normalize(rl.Vector2Subtract(to, from))
+	dash := thickness * 6
+
+	for d := float32(0); d < length; d += dash * 2 {
+		start := rl.Vector2Add(from, rl.Vector2Scale(direction, d))
+		end := rl.Vector2Add(from, rl.Vector2Scale(direction, min(d+dash, length)))
+		rl.DrawLineEx(start, end, thickness, color)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const PortalCooldown = 0.5 // seconds before a stone that came out of a portal can go into one again
+
+// Portal - one end of a pair, a stone whose center gets into it comes out of the other end
+type Portal struct {
+	pos    rl.Vector2
+	radius float32
+	facing rl.Vector2 // the stones come out going this way, and go straight in going the opposite way
+}
+
+// PortalPair - the two ends are the same to the stones, either one leads to the other
+type PortalPair struct {
+	ends  [2]Portal
+	color rl.Color
+}
+
+func vectorAngle(v rl.Vector2) float32 {
+	return float32(math.Atan2(float64(v.Y), float64(v.X)))
+}
+
+// throughPortal - the velocity coming out of the other end. it's turned as much as the two facings are apart,
+// so going straight in means coming straight out
+func (pair PortalPair) throughPortal(entry int, velocity rl.Vector2) rl.Vector2 {
+	in, out := pair.ends[entry], pair.ends[1-entry]
+	facing := rl.Vector2Normalize(out.facing)
+
+	turn := vectorAngle(facing) - vectorAngle(rl.Vector2Negate(in.facing))
+	turned := rl.Vector2Rotate(velocity, turn)
+
+	// it would go right back in otherwise
+	if along := rl.Vector2DotProduct(turned, facing); along < 0 {
+		turned = rl.Vector2Subtract(turned, rl.Vector2Scale(facing, 2*along))
+	}
+	return turned
+}
+
+// exitPos - where a stone leaving with the velocity comes out, on the edge of the portal
+func (portal Portal) exitPos(velocity rl.Vector2) rl.Vector2 {
+	return rl.Vector2Add(portal.pos, rl.Vector2Scale(rl.Vector2Normalize(velocity), portal.radius))
+}
+
+// throughPortals - a moving stone whose center got into a portal is moved to the other end.
+// returns the pair and the end it went into
+func (level *Level) throughPortals(s *Stone, dt float32) (PortalPair, int, bool) {
+	s.portalCooldown = max(s.portalCooldown-dt, 0)
+	if s.portalCooldown > 0 || rl.Vector2Length(s.velocity) == 0 {
+		return PortalPair{}, 0, false
+	}
+
+	for _, pair := range level.levelSettings.portals {
+		for entry, portal := range pair.ends {
+			if rl.Vector2Distance(s.pos, portal.pos) >= portal.radius {
+				continue
+			}
+
+			s.velocity = pair.throughPortal(entry, s.velocity)
+			s.pos = pair.ends[1-entry].exitPos(s.velocity)
+			s.portalCooldown = PortalCooldown
+			return pair, entry, true
+		}
+	}
+
+	return PortalPair{}, 0, false
+}
+
+// enterPortals - the stones going through the portals, with a burst of sparks on both ends
+func (level *Level) enterPortals(s *Stone) {
+	if pair, entry, ok := level.throughPortals(s, rl.GetFrameTime()); ok {
+		level.portalBurst(pair.ends[entry], pair.color)
+		level.portalBurst(pair.ends[1-entry], pair.color)
+	}
+}
+
+// portalBurst - a ring of particles flying off the portal a stone went through
+func (level *Level) portalBurst(portal Portal, color rl.Color) {
+	for range particleCount(40) {
+		level.allParticles = append(level.allParticles, NewParticle(
+			portal.pos,
+			rand.Float32()*360,
+			MaxParticleSpeed*(0.5+rand.Float32()),
+			0.8,
+			portal.radius*0.12,
+			color,
+		))
+	}
+}
+
+// updatePortals - the portals keep pulling in a few sparks, so they read as open
+func (level *Level) updatePortals() {
+	for _, pair := range level.levelSettings.portals {
+		for _, portal := range pair.ends {
+			for range particleCount(2) {
+				angle := rand.Float32() * 360
+				rim := rl.Vector2Add(portal.pos, rl.Vector2Rotate(rl.NewVector2(portal.radius, 0), -angle*rl.Deg2rad))
+				// particles go by the angle counterclockwise, so this one points back at the center
+				level.allParticles = append(level.allParticles, NewParticle(
+					rim,
+					angle+180,
+					portal.radius/40,
+					0.6,
+					portal.radius*0.06,
+					pair.color,
+				))
+			}
+		}
+	}
+}
+
+// rayToPortal - the first portal the ray gets into, the distance to it, and the position and direction it comes out with
+func (level *Level) rayToPortal(origin, direction rl.Vector2) (float32, rl.Vector2, rl.Vector2, bool) {
+	bestT := float32(math.Inf(1))
+	var exitPos, exitDirection rl.Vector2
+	found := false
+
+	for _, pair := range level.levelSettings.portals {
+		for entry, portal := range pair.ends {
+			// just came out of it, on its edge
+			if rl.Vector2Distance(origin, portal.pos) <= portal.radius*1.01 {
+				continue
+			}
+			if t, ok := rayToCircle(origin, direction, portal.pos, portal.radius); ok && t < bestT {
+				bestT, found = t, true
+				exitDirection = rl.Vector2Normalize(pair.throughPortal(entry, direction))
+				exitPos = pair.ends[1-entry].exitPos(exitDirection)
+			}
+		}
+	}
+
+	return bestT, exitPos, exitDirection, found
+}
+
+func (level *Level) drawPortals() {
+	for _, pair := range level.levelSettings.portals {
+		for _, portal := range pair.ends {
+			rl.DrawCircleV(portal.pos, portal.radius, rl.ColorAlpha(pair.color, 0.25))
+			rl.DrawRing(portal.pos, portal.radius*0.88, portal.radius, 0, 360, 0, pair.color)
+
+			// the way the stones come out
+			facing := rl.Vector2Normalize(portal.facing)
+			side := rl.NewVector2(-facing.Y, facing.X)
+			tip := rl.Vector2Add(portal.pos, rl.Vector2Scale(facing, portal.radius*0.5))
+			back := rl.Vector2Subtract(tip, rl.Vector2Scale(facing, portal.radius*0.35))
+			rl.DrawLineEx(tip, rl.Vector2Add(back, rl.Vector2Scale(side, portal.radius*0.3)), portal.radius*0.08, pair.color)
+			rl.DrawLineEx(tip, rl.Vector2Subtract(back, rl.Vector2Scale(side, portal.radius*0.3)), portal.radius*0.08, pair.color)
+		}
+	}
+}
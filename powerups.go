@@ -0,0 +1,303 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type PowerUpKind = uint8
+
+const (
+	HealPowerUp         PowerUpKind = iota // gives the stone some of its life back
+	DoubleDamagePowerUp PowerUpKind = iota // the next stone it hits takes twice the damage
+	ExtraTurnPowerUp    PowerUpKind = iota // the owner of the stone plays again
+	MassBoostPowerUp    PowerUpKind = iota // the stone is heavier for a while
+	FreezePowerUp       PowerUpKind = iota // one of the enemy stones can't be played for a turn
+	TotalPowerUpKinds   PowerUpKind = iota
+)
+
+const PowerUpSpawnChance = 0.35 // checked at the start of every turn
+const MaxPowerUpsOnField = 2
+const HealShare float32 = 0.3 // of the starting life
+const MassBoostFactor float32 = 2
+const MassBoostTurns uint8 = 3 // the turn it's picked up in, the opponent's and the owner's next one
+const FreezeTurns uint8 = 2    // the turn it's picked up in and the opponent's next one
+
+var PowerUpColors = [TotalPowerUpKinds]rl.Color{
+	HealPowerUp:         rl.NewColor(90, 200, 110, 255),
+	DoubleDamagePowerUp: rl.NewColor(230, 80, 50, 255),
+	ExtraTurnPowerUp:    rl.NewColor(250, 200, 40, 255),
+	MassBoostPowerUp:    rl.NewColor(110, 90, 80, 255),
+	FreezePowerUp:       rl.NewColor(120, 200, 255, 255),
+}
+
+type PowerUp struct {
+	kind PowerUpKind
+	pos  rl.Vector2
+}
+
+func powerUpRadius() float32 {
+	return StoneRadius * 0.45
+}
+
+// playArea - where the stones can be, the arena for bordered levels and the canvas otherwise
+func (level *Level) playArea() Arena {
+	if level.levelSettings.isBordered {
+		return level.levelSettings.arena
+	}
+	return rectangleArena(rl.NewRectangle(0, 0, CanvasWidth, CanvasHeight))
+}
+
+// canBePlayed - frozen and locked stones sit the turn out
+func (s *Stone) canBePlayed() bool {
+	return !s.isDead && s.frozenTurns == 0 && !s.locked
+}
+
+// beginTurn - the stones came to rest after a shot, the shot is judged by the turn rules, the timed effects run out,
+// the new player's stones are locked, the arena may shrink and a new power-up may show up
+func (level *Level) beginTurn() {
+	if level.turnPending {
+		level.endTurn()
+		level.turnPending = false
+		level.achievementTracker.shotJudged()
+	}
+
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.frozenTurns > 0 {
+			stone.frozenTurns--
+		}
+		if stone.massBoostTurns > 0 {
+			stone.massBoostTurns--
+			if stone.massBoostTurns == 0 {
+				stone.mass /= MassBoostFactor
+			}
+		}
+	}
+
+	for player, extra := range level.extraTurn {
+		if extra {
+			level.playerTurn = Player(player)
+			level.extraTurn[player] = false
+		}
+	}
+	level.thawIfStuck(level.playerTurn)
+
+	level.lockStones()
+	level.shrinkOnTurn()
+	level.spawnPowerUp()
+}
+
+// spawnPowerUp - places one of the level's power-ups on a free spot, drawn with the level's rng
+func (level *Level) spawnPowerUp() {
+	kinds := level.levelSettings.powerUps
+	if len(kinds) == 0 || len(level.powerUps) >= MaxPowerUpsOnField || level.rng.Float32() > PowerUpSpawnChance {
+		return
+	}
+
+	kind := kinds[level.rng.IntN(len(kinds))]
+	playArea := level.playArea()
+	area := playArea.bounds()
+	margin := StoneRadius * 2
+	radius := powerUpRadius()
+
+	// a few tries, the field can be too crowded for it
+	for range 20 {
+		pos := rl.NewVector2(
+			area.X+margin+level.rng.Float32()*(area.Width-margin*2),
+			area.Y+margin+level.rng.Float32()*(area.Height-margin*2),
+		)
+
+		// the bounds of a round or holed arena cover more than the arena
+		free := playArea.contains(pos) && playArea.distanceToWall(pos) >= margin
+		for _, stone := range level.stones {
+			if !stone.isDead && rl.CheckCollisionCircles(pos, radius*2, stone.pos, stone.radius) {
+				free = false
+				break
+			}
+		}
+		for _, other := range level.powerUps {
+			if rl.CheckCollisionCircles(pos, radius*2, other.pos, radius*2) {
+				free = false
+				break
+			}
+		}
+
+		if free {
+			level.powerUps = append(level.powerUps, PowerUp{kind: kind, pos: pos})
+			return
+		}
+	}
+}
+
+// collectPowerUps - the moving stones pick up whatever they pass over
+func (level *Level) collectPowerUps() {
+	remaining := level.powerUps[:0]
+	for _, powerUp := range level.powerUps {
+		collected := false
+		for i := range level.stones {
+			stone := &level.stones[i]
+			if stone.isDead || rl.Vector2Length(stone.velocity) == 0 {
+				continue
+			}
+			if rl.CheckCollisionCircles(stone.pos, stone.radius, powerUp.pos, powerUpRadius()) {
+				level.applyPowerUp(stone, powerUp.kind)
+				collected = true
+				break
+			}
+		}
+		if !collected {
+			remaining = append(remaining, powerUp)
+		}
+	}
+	level.powerUps = remaining
+}
+
+func (level *Level) applyPowerUp(s *Stone, kind PowerUpKind) {
+	switch kind {
+	case HealPowerUp:
+		startingLife := level.levelSettings.rules.startingLife
+		healed := min(s.life+startingLife*HealShare, startingLife) - s.life
+		if healed > 0 {
+			s.life += healed
+			level.matchLog.record(level, MatchEvent{kind: StoneHealed, stoneId: s.id, playerId: s.playerId, amount: healed})
+		}
+	case DoubleDamagePowerUp:
+		s.charged = true
+	case ExtraTurnPowerUp:
+		level.extraTurn[s.playerId] = true
+	case MassBoostPowerUp:
+		if s.massBoostTurns == 0 {
+			s.mass *= MassBoostFactor
+		}
+		s.massBoostTurns = MassBoostTurns
+	case FreezePowerUp:
+		level.freezeEnemyOf(s.playerId)
+	}
+}
+
+// freezeEnemyOf - freezes one of the opponent's stones, but never their last playable one
+func (level *Level) freezeEnemyOf(player Player) {
+	candidates := []int{}
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.playerId != player && stone.canBePlayed() {
+			candidates = append(candidates, i)
+		}
+	}
+
+	if len(candidates) < 2 {
+		return
+	}
+
+	level.stones[candidates[level.rng.IntN(len(candidates))]].frozenTurns = FreezeTurns
+}
+
+// thawIfStuck - the freeze leaves the player one stone to play when it's picked up, but that one may be
+// knocked off in the same shot. their stones thaw then, the match would be stuck otherwise
+func (level *Level) thawIfStuck(player Player) {
+	frozen := []int{}
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.playerId != player || stone.isDead {
+			continue
+		}
+		if stone.frozenTurns == 0 {
+			return
+		}
+		frozen = append(frozen, i)
+	}
+
+	for _, ix := range frozen {
+		level.stones[ix].frozenTurns = 0
+	}
+}
+
+// spendCharge - the damage multiplier of a hit, a double damage charge is used up by it
+func spendCharge(s *Stone) float32 {
+	if !s.charged {
+		return 1
+	}
+	s.charged = false
+	return 2
+}
+
+// pathCrossesPowerUp - whether a stone going from one point to the other would pick up a power-up on the way
+func (level *Level) pathCrossesPowerUp(from, to rl.Vector2, radius float32) bool {
+	for _, powerUp := range level.powerUps {
+		if rl.CheckCollisionCircleLine(powerUp.pos, powerUpRadius()+radius, from, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func (level *Level) drawPowerUps() {
+	radius := powerUpRadius()
+	pulse := float32(1)
+	if !config.Accessibility.ReduceMotion {
+		pulse = 1 + 0.08*float32(math.Sin(float64(level.clock.seconds())*4))
+	}
+
+	for _, powerUp := range level.powerUps {
+		r := radius * pulse
+		color := PowerUpColors[powerUp.kind]
+		rl.DrawCircleV(powerUp.pos, r, rl.ColorAlpha(color, 0.35))
+		rl.DrawRing(powerUp.pos, r*0.85, r, 0, 360, 0, color)
+		drawPowerUpIcon(powerUp.pos, r*0.6, powerUp.kind, dimWhite(230))
+	}
+}
+
+// drawPowerUpIcon - shapes instead of text, so they read at any size and without colors
+func drawPowerUpIcon(pos rl.Vector2, size float32, kind PowerUpKind, color rl.Color) {
+	thickness := size * 0.3
+
+	switch kind {
+	case HealPowerUp:
+		rl.DrawRectangleV(rl.NewVector2(pos.X-size, pos.Y-thickness/2), rl.NewVector2(size*2, thickness), color)
+		rl.DrawRectangleV(rl.NewVector2(pos.X-thickness/2, pos.Y-size), rl.NewVector2(thickness, size*2), color)
+	case DoubleDamagePowerUp:
+		// two chevrons
+		for _, dx := range []float32{-size * 0.4, size * 0.4} {
+			rl.DrawLineEx(rl.NewVector2(pos.X+dx-size*0.4, pos.Y-size*0.7), rl.NewVector2(pos.X+dx+size*0.2, pos.Y), thickness*0.7, color)
+			rl.DrawLineEx(rl.NewVector2(pos.X+dx+size*0.2, pos.Y), rl.NewVector2(pos.X+dx-size*0.4, pos.Y+size*0.7), thickness*0.7, color)
+		}
+	case ExtraTurnPowerUp:
+		// a circular arrow
+		rl.DrawRing(pos, size*0.7, size*0.7+thickness*0.7, 30, 320, 0, color)
+		tip := rl.NewVector2(pos.X+size*0.75*float32(math.Cos(30*math.Pi/180)), pos.Y+size*0.75*float32(math.Sin(30*math.Pi/180)))
+		rl.DrawPoly(tip, 3, thickness*1.2, 120, color)
+	case MassBoostPowerUp:
+		// a weight
+		rl.DrawRectangleV(rl.NewVector2(pos.X-size*0.7, pos.Y-size*0.3), rl.NewVector2(size*1.4, size*1.0), color)
+		rl.DrawRing(rl.NewVector2(pos.X, pos.Y-size*0.45), size*0.2, size*0.35, 180, 360, 0, color)
+	case FreezePowerUp:
+		// a snowflake
+		for i := range 3 {
+			angle := float64(i) * math.Pi / 3
+			d := rl.NewVector2(size*float32(math.Cos(angle)), size*float32(math.Sin(angle)))
+			rl.DrawLineEx(rl.Vector2Subtract(pos, d), rl.Vector2Add(pos, d), thickness*0.6, color)
+		}
+	}
+}
+
+// drawStoneEffects - what the picked up power-ups did to a stone
+func drawStoneEffects(s *Stone, time float32) {
+	if s.charged {
+		alpha := float32(0.8)
+		if !config.Accessibility.ReduceMotion {
+			alpha = 0.55 + 0.35*float32(math.Sin(float64(time)*10))
+		}
+		rl.DrawRing(s.pos, s.radius*1.02, s.radius*1.1, 0, 360, 0, rl.ColorAlpha(PowerUpColors[DoubleDamagePowerUp], alpha))
+	}
+
+	if s.massBoostTurns > 0 {
+		rl.DrawRing(s.pos, s.radius*1.0, s.radius*1.12, 0, 360, 0, PowerUpColors[MassBoostPowerUp])
+	}
+
+	if s.frozenTurns > 0 {
+		rl.DrawCircleV(s.pos, s.radius, rl.ColorAlpha(PowerUpColors[FreezePowerUp], 0.45))
+		drawPowerUpIcon(s.pos, s.radius*0.5, FreezePowerUp, dimWhite(220))
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"math/rand/v2"
+)
+
+// levelRng - the source of randomness for everything that affects the gameplay.
+// unlike the one used for shards and particles, its state is saved with the match
+type levelRng struct {
+	source *rand.PCG
+	*rand.Rand
+}
+
+func newLevelRng(seed1, seed2 uint64) levelRng {
+	source := rand.NewPCG(seed1, seed2)
+	return levelRng{
+		source: source,
+		Rand:   rand.New(source),
+	}
+}
+
+func (r levelRng) state() ([]byte, error) {
+	return r.source.MarshalBinary()
+}
+
+func (r levelRng) restore(state []byte) error {
+	return r.source.UnmarshalBinary(state)
+}
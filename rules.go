@@ -0,0 +1,56 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// LevelRules - how much life the stones have and how they lose it
+type LevelRules struct {
+	startingLife   float32
+	damageScale    float32 // applied to every kind of damage
+	attackerShare  float32 // of the collision damage, taken by the faster stone
+	defenderShare  float32 // of the collision damage, taken by the slower stone
+	wallShare      float32 // of the impact, taken by a stone hitting the border
+	wallAngle      float32 // 0: every wall hit counts the same, 1: only the head-on part of the hit counts
+	minImpactSpeed float32 // of MaxPushVelocityAllowed, slower impacts do no damage
+	indestructible bool    // the stones never lose life, only ring-outs count
+}
+
+func defaultRules() LevelRules {
+	return LevelRules{
+		startingLife:   100,
+		damageScale:    1,
+		attackerShare:  0.2,
+		defenderShare:  1,
+		wallShare:      0.3,
+		wallAngle:      0,
+		minImpactSpeed: 0,
+		indestructible: false,
+	}
+}
+
+// borderedRules - the stones bounce off the walls all the time, glancing hits shouldn't wear them down as much
+func borderedRules() LevelRules {
+	rules := defaultRules()
+	rules.wallAngle = 0.6
+	return rules
+}
+
+// impactDamage - the damage of an impact at the given speed, before the shares are applied
+func (rules LevelRules) impactDamage(speed float32) float32 {
+	if speed < rules.minImpactSpeed*MaxPushVelocityAllowed {
+		return 0
+	}
+	return rl.Clamp(speed, 0, MaxPushVelocityAllowed) * 2
+}
+
+// wallDamage - headOn is the part of the speed going into the wall, 0..1
+func (rules LevelRules) wallDamage(speed, headOn float32) float32 {
+	angleFactor := 1 - rules.wallAngle + rules.wallAngle*headOn
+	return rules.impactDamage(speed) * rules.wallShare * angleFactor
+}
+
+// lifeShare - how much of its starting life the stone has left
+func (level *Level) lifeShare(s *Stone) float32 {
+	return s.life / level.levelSettings.rules.startingLife
+}
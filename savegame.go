@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const saveFileName = "savegame.json"
+
+// the fields added to savedMatch later are missing from the older saves and load as their zero values,
+// so those have to mean what a fresh match starts with. bump this only when a field changes meaning,
+// the saves from before it are then refused instead of being loaded half-way
+const (
+	saveVersion       = 3
+	oldestSaveVersion = 3 // v1 was in window pixels and v2 kept the match time in seconds
+)
+
+type savedStone struct {
+	Id       uint8     `json:"id"`
+	PlayerId Player    `json:"player"`
+	IsDead   bool      `json:"dead"`
+	Mass     float32   `json:"mass"`
+	Radius   float32   `json:"radius"`
+	Life     float32   `json:"life"`
+	X        float32   `json:"x"`
+	Y        float32   `json:"y"`
+	VX       float32   `json:"vx"`
+	VY       float32   `json:"vy"`
+	Kind     StoneKind `json:"kind"`
+	Shielded bool      `json:"shielded"`
+	Charged  bool      `json:"charged"`
+	Boosted  uint8     `json:"boosted"` // turns left with the mass boost, Mass is the boosted one
+	Frozen   uint8     `json:"frozen"`
+	Locked   bool      `json:"locked"`
+}
+
+type savedPowerUp struct {
+	Kind PowerUpKind `json:"kind"`
+	X    float32     `json:"x"`
+	Y    float32     `json:"y"`
+}
+
+type savedEvent struct {
+	Kind     MatchEventKind `json:"kind"`
+	Time     float32        `json:"time"`
+	Shot     int            `json:"shot"`
+	StoneId  uint8          `json:"stone"`
+	PlayerId Player         `json:"player"`
+	OtherId  uint8          `json:"other"`
+	ByStone  bool           `json:"byStone"`
+	Amount   float32        `json:"amount"`
+}
+
+type savedMatch struct {
+	Version        int                       `json:"version"`
+	SceneId        SceneId                   `json:"level"`
+	PlayerTurn     Player                    `json:"turn"`
+	Elapsed        time.Duration             `json:"elapsed"`
+	Rng            []byte                    `json:"rng"`
+	HitStoneMoving int                       `json:"hitStoneMoving"` // index into Stones, -1 if none
+	Stones         []savedStone              `json:"stones"`
+	InitialLife    [TotalPlayerCount]float32 `json:"initialLife"`
+	CurrentShot    int                       `json:"currentShot"`
+	Events         []savedEvent              `json:"events"`
+	PowerUps       []savedPowerUp            `json:"powerUps"`
+	ExtraTurn      [TotalPlayerCount]bool    `json:"extraTurn"`
+	SkipTurn       [TotalPlayerCount]bool    `json:"skipTurn"`
+	TurnPending    bool                      `json:"turnPending"`
+	Overtime       bool                      `json:"overtime"`
+	OvertimeScore  [TotalPlayerCount]uint8   `json:"overtimeScore"`
+	ArenaScale     float32                   `json:"arenaScale"`
+	ArenaTarget    float32                   `json:"arenaTarget"`
+	NextShrinkAt   time.Duration             `json:"nextShrinkAt"`
+}
+
+// canBeSaved - there's nothing to resume in a finished match or in the main menu demo
+func (level *Level) canBeSaved() bool {
+	return level.levelSettings.sceneId != Main && (level.status == Initialized || level.status == Stopped)
+}
+
+func (level *Level) toSavedMatch(window *Window) (savedMatch, error) {
+	rngState, err := level.rng.state()
+	if err != nil {
+		return savedMatch{}, err
+	}
+
+	saved := savedMatch{
+		Version:        saveVersion,
+		SceneId:        level.levelSettings.sceneId,
+		PlayerTurn:     level.playerTurn,
+		Elapsed:        level.clock.elapsed,
+		Rng:            rngState,
+		HitStoneMoving: -1,
+		InitialLife:    level.matchLog.initialLife,
+		CurrentShot:    level.matchLog.currentShot,
+		ExtraTurn:      level.extraTurn,
+		SkipTurn:       level.skipTurn,
+		TurnPending:    level.turnPending,
+		Overtime:       level.overtime,
+		OvertimeScore:  level.overtimeScore,
+		ArenaScale:     level.arenaScale,
+		ArenaTarget:    level.arenaTarget,
+		NextShrinkAt:   level.nextShrinkAt,
+	}
+
+	for i, stone := range level.stones {
+		if level.hitStoneMoving == &level.stones[i] {
+			saved.HitStoneMoving = i
+		}
+
+		saved.Stones = append(saved.Stones, savedStone{
+			Id:       stone.id,
+			PlayerId: stone.playerId,
+			IsDead:   stone.isDead,
+			Mass:     stone.mass,
+			Radius:   stone.radius,
+			Life:     stone.life,
+			X:        stone.pos.X,
+			Y:        stone.pos.Y,
+			VX:       stone.velocity.X,
+			VY:       stone.velocity.Y,
+			Kind:     stone.kind,
+			Shielded: stone.shielded,
+			Charged:  stone.charged,
+			Boosted:  stone.massBoostTurns,
+			Frozen:   stone.frozenTurns,
+			Locked:   stone.locked,
+		})
+	}
+
+	for _, powerUp := range level.powerUps {
+		saved.PowerUps = append(saved.PowerUps, savedPowerUp{Kind: powerUp.kind, X: powerUp.pos.X, Y: powerUp.pos.Y})
+	}
+
+	for _, e := range level.matchLog.events {
+		saved.Events = append(saved.Events, savedEvent{
+			Kind:     e.kind,
+			Time:     e.time,
+			Shot:     e.shot,
+			StoneId:  e.stoneId,
+			PlayerId: e.playerId,
+			OtherId:  e.otherId,
+			ByStone:  e.byStone,
+			Amount:   e.amount,
+		})
+	}
+
+	return saved, nil
+}
+
+// restore - puts the level back into the saved state.
+// the level settings and the players come from the scene, only the match state is taken from the save
+func (level *Level) restore(saved *savedMatch, window *Window) error {
+	if err := level.rng.restore(saved.Rng); err != nil {
+		return err
+	}
+
+	stones := []Stone{}
+	for _, s := range saved.Stones {
+		stone := newStone(s.Id, s.X, s.Y, s.Radius, s.Mass, s.PlayerId)
+		stone.isDead = s.IsDead
+		stone.life = s.Life
+		stone.velocity = rl.NewVector2(s.VX, s.VY)
+		stone.kind = s.Kind
+		stone.shielded = s.Shielded
+		stone.charged = s.Charged
+		stone.massBoostTurns = s.Boosted
+		stone.frozenTurns = s.Frozen
+		stone.locked = s.Locked
+		stones = append(stones, stone)
+	}
+
+	level.setStones(stones)
+	level.matchLog.initialLife = saved.InitialLife
+	level.matchLog.currentShot = saved.CurrentShot
+	for _, e := range saved.Events {
+		event := MatchEvent{
+			kind:     e.Kind,
+			time:     e.Time,
+			shot:     e.Shot,
+			stoneId:  e.StoneId,
+			playerId: e.PlayerId,
+			otherId:  e.OtherId,
+			byStone:  e.ByStone,
+			amount:   e.Amount,
+		}
+		level.matchLog.events = append(level.matchLog.events, event)
+		// the tracker is fed the match so far again, the stones lost before the save count against flawless
+		level.achievementTracker.observe(level, event)
+	}
+
+	level.powerUps = []PowerUp{}
+	for _, p := range saved.PowerUps {
+		if p.Kind < TotalPowerUpKinds {
+			level.powerUps = append(level.powerUps, PowerUp{kind: p.Kind, pos: rl.NewVector2(p.X, p.Y)})
+		}
+	}
+	level.extraTurn = saved.ExtraTurn
+	level.skipTurn = saved.SkipTurn
+	level.turnPending = saved.TurnPending
+	level.overtime = saved.Overtime
+	level.overtimeScore = saved.OvertimeScore
+
+	// saves from before the shrinking arena have none of it
+	if saved.ArenaTarget > 0 {
+		level.arenaScale = saved.ArenaScale
+		level.arenaTarget = saved.ArenaTarget
+		level.nextShrinkAt = saved.NextShrinkAt
+		level.levelSettings.arena = level.arenaFull.scaled(level.arenaScale)
+	}
+
+	level.playerTurn = saved.PlayerTurn
+	level.clock.elapsed = saved.Elapsed
+	if saved.HitStoneMoving >= 0 && saved.HitStoneMoving < len(level.stones) {
+		level.hitStoneMoving = &level.stones[saved.HitStoneMoving]
+	}
+
+	level.checkStonesForMovements()
+	level.status = Initialized
+
+	return nil
+}
+
+func saveMatch(level *Level, window *Window) {
+	saved, err := level.toSavedMatch(window)
+	if err == nil {
+		err = saveJSON(saveFileName, saved)
+	}
+	if err != nil {
+		rl.TraceLog(rl.LogWarning, "the match could not be saved: %v", err)
+		return
+	}
+	level.ownsSave = true
+}
+
+func loadMatch() (*savedMatch, error) {
+	saved := savedMatch{}
+	if err := loadJSON(saveFileName, &saved); err != nil {
+		return nil, err
+	}
+
+	if saved.Version < oldestSaveVersion || saved.Version > saveVersion {
+		return nil, fmt.Errorf("save format v%d is not supported (expected v%d to v%d)", saved.Version, oldestSaveVersion, saveVersion)
+	}
+
+	if _, ok := LevelProgression[saved.SceneId]; !ok {
+		return nil, fmt.Errorf("unknown level id %d", saved.SceneId)
+	}
+
+	return &saved, nil
+}
+
+func hasSavedMatch() bool {
+	path, err := storagePath(saveFileName)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// dropSave - deletes the save if it's this match's, the one of another match is left for "continue"
+func (level *Level) dropSave() {
+	if level.ownsSave {
+		deleteSavedMatch()
+		level.ownsSave = false
+	}
+}
+
+func deleteSavedMatch() {
+	path, err := storagePath(saveFileName)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		rl.TraceLog(rl.LogWarning, "the saved match could not be deleted: %v", err)
+	}
+}
@@ -8,10 +8,23 @@ const (
 	LevelBasic      SceneId = iota
 	LevelBordered   SceneId = iota
 	LevelTimeLimit  SceneId = iota
+	LevelShrinking  SceneId = iota
+	LevelPortals    SceneId = iota
+	LevelGravity    SceneId = iota
+	LevelStoneKinds SceneId = iota
+	LevelPowerUps   SceneId = iota
+	LevelSurfaces   SceneId = iota
+	LevelPillar     SceneId = iota
+	LevelPockets    SceneId = iota
 	Transition      SceneId = iota
 	Options         SceneId = iota
+	Achievements    SceneId = iota
+	Controls        SceneId = iota
+	Customize       SceneId = iota
 	Quit            SceneId = iota
 	TotalSceneCount SceneId = iota
+	// not a real scene, the main menu resolves it to the level of the saved match
+	Continue SceneId = iota
 )
 
 type Scene interface {
@@ -22,3 +35,9 @@ type Scene interface {
 	Draw(window *Window)
 	Teardown(window *Window)
 }
+
+// LevelScene - a scene hosting a match that can be saved and resumed
+type LevelScene interface {
+	Scene
+	GetLevel() *Level
+}
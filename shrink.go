@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const ArenaShrinkSpeed = 0.05 // of the full size per second, the walls close in slowly enough to watch
+
+// ShrinkSettings - how the arena of a bordered level closes in, it never does when both intervals are 0
+type ShrinkSettings struct {
+	everyTurns int           // shrinks after this many shots
+	every      time.Duration // shrinks this often
+	step       float32       // of the full size, taken off every time
+	minScale   float32       // it stops shrinking at this size
+}
+
+func (shrink ShrinkSettings) enabled() bool {
+	return shrink.everyTurns > 0 || shrink.every > 0
+}
+
+// shrinkArena - the next shrink is queued, updateArena moves the walls there
+func (level *Level) shrinkArena() {
+	shrink := level.levelSettings.shrink
+	level.arenaTarget = max(level.arenaTarget-shrink.step, shrink.minScale)
+}
+
+// updateArena - starts the timed shrinks and moves the walls towards the target
+func (level *Level) updateArena(dt float32) {
+	shrink := level.levelSettings.shrink
+	if !shrink.enabled() {
+		return
+	}
+
+	if shrink.every > 0 && level.clock.elapsed >= level.nextShrinkAt {
+		level.shrinkArena()
+		level.nextShrinkAt += shrink.every
+	}
+
+	if level.arenaScale > level.arenaTarget {
+		level.arenaScale = max(level.arenaScale-ArenaShrinkSpeed*dt, level.arenaTarget)
+		level.levelSettings.arena = level.arenaFull.scaled(level.arenaScale)
+	}
+}
+
+// shrinkOnTurn - the turn based shrinks, called once the stones of a shot stop.
+// the first one comes right after the everyTurns-th shot
+func (level *Level) shrinkOnTurn() {
+	everyTurns := level.levelSettings.shrink.everyTurns
+	if everyTurns > 0 && level.matchLog.shotsFired()%everyTurns == 0 {
+		level.shrinkArena()
+	}
+}
+
+// sweptOver - the shrinking walls pass over the resting stones instead of shoving them along,
+// so the ones they close in on end up caught outside
+func (level *Level) sweptOver(s *Stone) bool {
+	return level.levelSettings.shrink.enabled() && rl.Vector2Length(s.velocity) == 0
+}
+
+// caughtOutside - the walls closed in past the center of the stone or it went out through an open side,
+// there's no saving it
+func (level *Level) caughtOutside(s *Stone) bool {
+	arena := level.levelSettings.arena
+	if !level.levelSettings.isBordered || !(level.levelSettings.shrink.enabled() || arena.hasOpenSides()) {
+		return false
+	}
+	return !arena.contains(s.pos)
+}
+
+// drawArena - the full size the walls started at is kept faintly, and the next size is outlined when it's close
+func (level *Level) drawArena(screenWidth, screenHeight float32) {
+	shrink := level.levelSettings.shrink
+	if !shrink.enabled() {
+		return
+	}
+
+	if level.arenaScale < 1 {
+		level.arenaFull.draw(screenWidth/512, rl.NewColor(0, 0, 0, 70))
+	}
+
+	if level.arenaTarget <= shrink.minScale {
+		return
+	}
+
+	next := ""
+	soon := false
+	if shrink.everyTurns > 0 {
+		turnsLeft := shrink.everyTurns - level.matchLog.shotsFired()%shrink.everyTurns
+		next = fmt.Sprintf("the arena shrinks in %d turns", turnsLeft)
+		if turnsLeft == 1 {
+			next = "the arena shrinks after this turn"
+		}
+		soon = turnsLeft == 1
+	} else {
+		secondsLeft := int(math.Ceil((level.nextShrinkAt - level.clock.elapsed).Seconds()))
+		next = fmt.Sprintf("the arena shrinks in %ds", secondsLeft)
+		soon = secondsLeft <= 3
+	}
+
+	if soon {
+		level.arenaFull.scaled(max(level.arenaTarget-shrink.step, shrink.minScale)).draw(screenWidth/512, dimWhite(90))
+	}
+
+	fontSize := FontSize / 8
+	measured := rl.MeasureTextEx(rl.GetFontDefault(), next, fontSize, fontSize/10)
+	rl.DrawTextEx(
+		rl.GetFontDefault(),
+		next,
+		rl.NewVector2((screenWidth-measured.X)/2, screenHeight-measured.Y*2),
+		fontSize,
+		fontSize/10,
+		dimWhite(110),
+	)
+}
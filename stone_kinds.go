@@ -0,0 +1,223 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type StoneKind = uint8
+
+const (
+	NormalStone     StoneKind = iota
+	HeavyStone      StoneKind = iota // slow to launch, pushes everything around and hits hard
+	LightStone      StoneKind = iota // flies fast but bounces off the others
+	ExplosiveStone  StoneKind = iota // damages everything around it when its life runs out
+	SplitterStone   StoneKind = iota // breaks into two smaller stones when its life runs out
+	ShieldedStone   StoneKind = iota // the first hit only does a fraction of the damage
+	TotalStoneKinds StoneKind = iota
+)
+
+// StoneKindInfo - how a kind differs from a normal stone, all of them are multipliers
+type StoneKindInfo struct {
+	mass        float32
+	radius      float32 // of StoneRadius
+	launchSpeed float32 // of the shot velocity
+	damage      float32 // of the damage it deals in collisions
+}
+
+var StoneKindList = [TotalStoneKinds]StoneKindInfo{
+	NormalStone:    {mass: 1, radius: 1, launchSpeed: 1, damage: 1},
+	HeavyStone:     {mass: 2.5, radius: 1.15, launchSpeed: 0.75, damage: 1.5},
+	LightStone:     {mass: 0.6, radius: 0.8, launchSpeed: 1.3, damage: 0.8},
+	ExplosiveStone: {mass: 1, radius: 1, launchSpeed: 1, damage: 1},
+	SplitterStone:  {mass: 1.2, radius: 1.05, launchSpeed: 1, damage: 1},
+	ShieldedStone:  {mass: 1, radius: 1, launchSpeed: 1, damage: 1},
+}
+
+const ExplosionRadiusScale = 4  // of the exploding stone's radius
+const ExplosionDamage = 45      // at the center, it fades out towards the edge
+const ExplosionImpulse = 12     // the speed given to the stones at the center
+const ShieldDamageFactor = 0.25 // of the first hit a shielded stone takes
+const SplitStoneScale = 0.7     // the radius of the halves, of the splitter's
+const SplitStoneLife = 0.4      // each half starts with this much of the starting life
+
+func kindInfo(s *Stone) StoneKindInfo {
+	if s.kind >= TotalStoneKinds {
+		return StoneKindList[NormalStone]
+	}
+	return StoneKindList[s.kind]
+}
+
+func newStoneOfKind(stoneId uint8, x, y float32, kind StoneKind, playerId Player, rules LevelRules) Stone {
+	info := StoneKindList[kind]
+	stone := newStone(stoneId, x, y, StoneRadius*info.radius, info.mass, playerId)
+	stone.life = rules.startingLife
+	stone.kind = kind
+	stone.shielded = kind == ShieldedStone
+	return stone
+}
+
+// pickStoneKinds - the kinds of a formation, drawn from the level's mix.
+// both players get the same ones so neither of them starts with an advantage
+func pickStoneKinds(levelSettings LevelSettings, rng levelRng) []StoneKind {
+	kinds := make([]StoneKind, levelSettings.stonesPerPlayer)
+	if len(levelSettings.stoneKinds) == 0 {
+		return kinds
+	}
+
+	for i := range kinds {
+		kinds[i] = levelSettings.stoneKinds[rng.IntN(len(levelSettings.stoneKinds))]
+	}
+	return kinds
+}
+
+// breakShield - the shield takes most of the first hit and is gone after it
+func breakShield(s *Stone, amount float32) float32 {
+	if !s.shielded || amount <= 0 {
+		return amount
+	}
+	s.shielded = false
+	return amount * ShieldDamageFactor
+}
+
+// explode - damages and pushes away the stones around an explosive stone that ran out of life.
+// an explosive stone caught in it goes off in the next update
+func (level *Level) explode(s *Stone, window *Window) {
+	reach := s.radius * ExplosionRadiusScale
+
+	for i := range level.stones {
+		other := &level.stones[i]
+		if other == s || other.isDead {
+			continue
+		}
+
+		direction := rl.Vector2Subtract(other.pos, s.pos)
+		distance := rl.Vector2Length(direction) - other.radius
+		if distance > reach {
+			continue
+		}
+
+		falloff := 1 - rl.Clamp(distance/reach, 0, 1)
+		other.velocity = rl.Vector2Add(other.velocity, rl.Vector2Scale(rl.Vector2Normalize(direction), ExplosionImpulse*falloff))
+		level.damageStone(other, ExplosionDamage*falloff, s)
+	}
+
+	for i := float32(0.0); i < 100; i += shardStep() {
+		part := NewShard(
+			s.pos,
+			3.6*i,
+			MaxParticleSpeed*2*rand.Float32(),
+			2,
+			MaxShardRadius*(rand.Float32()+0.5),
+			rl.Orange,
+			false,
+		)
+
+		level.allShards = append(level.allShards, part)
+	}
+
+	PlaySound(&stoneExplosionSfx, 1, window.sfxVolume)
+}
+
+// split - the two halves of a splitter that ran out of life, they fly apart along its path
+func (level *Level) split(s *Stone) []Stone {
+	halves := []Stone{}
+
+	direction := rl.Vector2Normalize(s.velocity)
+	if rl.Vector2Length(direction) == 0 {
+		direction = rl.NewVector2(1, 0)
+	}
+	side := rl.NewVector2(-direction.Y, direction.X)
+	speed := max(rl.Vector2Length(s.velocity), VelocityThresholdToStop*4)
+
+	for _, sign := range []float32{-1, 1} {
+		offset := rl.Vector2Scale(side, sign*s.radius*SplitStoneScale)
+		pos := rl.Vector2Add(s.pos, offset)
+
+		half := newStone(0, pos.X, pos.Y, s.radius*SplitStoneScale, s.mass*0.5, s.playerId)
+		half.life = level.levelSettings.rules.startingLife * SplitStoneLife
+		half.velocity = rl.Vector2Scale(rl.Vector2Rotate(direction, sign*math.Pi/6), speed)
+		halves = append(halves, half)
+	}
+
+	return halves
+}
+
+// addStones - appends the stones with fresh ids. the slice may move,
+// so the stones the level points at are looked up again
+func (level *Level) addStones(stones []Stone) {
+	selectedIx, hitMovingIx := -1, -1
+	for i := range level.stones {
+		if level.selectedStone == &level.stones[i] {
+			selectedIx = i
+		}
+		if level.hitStoneMoving == &level.stones[i] {
+			hitMovingIx = i
+		}
+	}
+
+	for _, stone := range stones {
+		stone.id = uint8(len(level.stones))
+		level.stones = append(level.stones, stone)
+		level.matchLog.record(level, MatchEvent{kind: StoneSpawned, stoneId: stone.id, playerId: stone.playerId, amount: stone.life})
+	}
+
+	if selectedIx != -1 {
+		level.selectedStone = &level.stones[selectedIx]
+	}
+	if hitMovingIx != -1 {
+		level.hitStoneMoving = &level.stones[hitMovingIx]
+	}
+}
+
+// drawStoneKind - the marks telling the kinds apart, drawn over the face of the stone
+func drawStoneKind(s *Stone, time float32) {
+	switch s.kind {
+	case HeavyStone:
+		// a thick dark band inside the ring
+		rl.DrawRing(s.pos, s.radius*0.8, s.radius*0.9, 0, 360, 0, rl.NewColor(0, 0, 0, 110))
+		rl.DrawRing(s.pos, s.radius*1.01, s.radius*1.08, 0, 360, 0, rl.NewColor(30, 30, 30, 200))
+	case LightStone:
+		// speed marks trailing behind
+		for i := range 3 {
+			angle := float64(150+i*30) * math.Pi / 180
+			from := rl.NewVector2(s.pos.X+s.radius*1.15*float32(math.Cos(angle)), s.pos.Y+s.radius*1.15*float32(math.Sin(angle)))
+			to := rl.NewVector2(from.X-s.radius*0.35, from.Y)
+			rl.DrawLineEx(from, to, s.radius*0.08, dimWhite(140))
+		}
+	case ExplosiveStone:
+		pulse := 0.5 + 0.5*float32(math.Sin(float64(time)*6))
+		if config.Accessibility.ReduceMotion {
+			pulse = 0.5
+		}
+		rl.DrawCircleV(s.pos, s.radius*(0.2+0.08*pulse), rl.NewColor(230, 41, 55, uint8(160+95*pulse)))
+		// the fuse spikes around the edge
+		const spikes = 8
+		for i := range spikes {
+			angle := float64(i) * 2 * math.Pi / spikes
+			direction := rl.NewVector2(float32(math.Cos(angle)), float32(math.Sin(angle)))
+			from := rl.Vector2Add(s.pos, rl.Vector2Scale(direction, s.radius*1.0))
+			to := rl.Vector2Add(s.pos, rl.Vector2Scale(direction, s.radius*1.2))
+			rl.DrawLineEx(from, to, s.radius*0.1, rl.NewColor(230, 41, 55, 255))
+		}
+	case SplitterStone:
+		// the crack it breaks along
+		points := []rl.Vector2{
+			rl.NewVector2(s.pos.X, s.pos.Y-s.radius),
+			rl.NewVector2(s.pos.X+s.radius*0.15, s.pos.Y-s.radius*0.4),
+			rl.NewVector2(s.pos.X-s.radius*0.15, s.pos.Y+s.radius*0.1),
+			rl.NewVector2(s.pos.X+s.radius*0.1, s.pos.Y+s.radius*0.5),
+			rl.NewVector2(s.pos.X, s.pos.Y+s.radius),
+		}
+		for i := 1; i < len(points); i++ {
+			rl.DrawLineEx(points[i-1], points[i], s.radius*0.08, rl.NewColor(20, 20, 20, 200))
+		}
+	case ShieldedStone:
+		if s.shielded {
+			rl.DrawRing(s.pos, s.radius*1.08, s.radius*1.16, 0, 360, 0, rl.NewColor(120, 200, 255, 200))
+			rl.DrawCircleV(s.pos, s.radius*1.16, rl.NewColor(120, 200, 255, 40))
+		}
+	}
+}
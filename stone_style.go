@@ -0,0 +1,308 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const stonesFileName = "stones.json"
+
+// NoAchievement - the style is available from the start
+const NoAchievement = TotalAchievement
+
+type RingStyle = uint8
+
+const (
+	SolidRing      RingStyle = iota
+	DoubleRing     RingStyle = iota
+	DashedRing     RingStyle = iota
+	CrownRing      RingStyle = iota // the crimped edge of a bottle cap
+	TotalRingStyle RingStyle = iota
+)
+
+type Emblem = uint8
+
+const (
+	NoEmblem     Emblem = iota
+	DotEmblem    Emblem = iota
+	SliceEmblem  Emblem = iota // the orange soda cap
+	WaveEmblem   Emblem = iota // the cola cap ribbon
+	StarEmblem   Emblem = iota
+	TotalEmblems Emblem = iota
+)
+
+// StyleOption - an entry of one of the customization lists
+type StyleOption struct {
+	key        string // stable name used in the save file
+	label      string
+	unlockedBy AchievementId
+}
+
+type PaletteOption struct {
+	StyleOption
+	palette PlayerColorPalette
+}
+
+var PaletteList = []PaletteOption{
+	{StyleOption{"ocean", "ocean", NoAchievement}, HumanPlayerPalette1},
+	{StyleOption{"maroon", "maroon", NoAchievement}, CpuPlayerPalette1},
+	{StyleOption{"cola", "cola", NoAchievement}, PlayerColorPalette{
+		primaryColor:   rl.NewColor(196, 30, 42, 255),
+		outerRingColor: rl.NewColor(240, 235, 230, 255),
+		lifeColor:      rl.NewColor(255, 250, 255, 255),
+		rocketColor:    rl.NewColor(230, 41, 55, 255),
+	}},
+	{StyleOption{"orange", "orange soda", NoAchievement}, PlayerColorPalette{
+		primaryColor:   rl.NewColor(240, 128, 24, 255),
+		outerRingColor: rl.NewColor(28, 70, 150, 255),
+		lifeColor:      rl.NewColor(255, 250, 255, 255),
+		rocketColor:    rl.Orange,
+	}},
+	{StyleOption{"lemon_lime", "lemon-lime", NoAchievement}, PlayerColorPalette{
+		primaryColor:   rl.NewColor(40, 140, 70, 255),
+		outerRingColor: rl.NewColor(220, 200, 40, 255),
+		lifeColor:      rl.NewColor(255, 250, 255, 255),
+		rocketColor:    rl.Lime,
+	}},
+	{StyleOption{"gold", "gold", Flawless}, PlayerColorPalette{
+		primaryColor:   rl.NewColor(212, 175, 55, 255),
+		outerRingColor: rl.NewColor(140, 105, 20, 255),
+		lifeColor:      rl.NewColor(255, 250, 235, 255),
+		rocketColor:    rl.Gold,
+	}},
+	{StyleOption{"jade", "jade", LifeTiebreak}, PlayerColorPalette{
+		primaryColor:   rl.NewColor(0, 150, 120, 255),
+		outerRingColor: rl.NewColor(10, 80, 70, 255),
+		lifeColor:      rl.NewColor(255, 250, 255, 255),
+		rocketColor:    rl.NewColor(80, 220, 180, 255),
+	}},
+}
+
+var RingStyleList = [TotalRingStyle]StyleOption{
+	SolidRing:  {"solid", "solid", NoAchievement},
+	DoubleRing: {"double", "double", NoAchievement},
+	DashedRing: {"dashed", "dashed", NoAchievement},
+	CrownRing:  {"crown", "bottle cap", FirstWin},
+}
+
+var EmblemList = [TotalEmblems]StyleOption{
+	NoEmblem:    {"none", "none", NoAchievement},
+	DotEmblem:   {"dot", "dot", NoAchievement},
+	SliceEmblem: {"slice", "slice", NoAchievement},
+	WaveEmblem:  {"wave", "wave", DoubleCushion},
+	StarEmblem:  {"star", "star", HatTrick},
+}
+
+func (option StyleOption) isUnlocked() bool {
+	return option.unlockedBy == NoAchievement || achievements.isUnlocked(option.unlockedBy)
+}
+
+// StoneStyle - what a player picked, the indices of the lists above
+type StoneStyle struct {
+	palette int
+	ring    RingStyle
+	emblem  Emblem
+}
+
+// StoneProfiles - the styles of the two people that can play on this machine.
+// player two's style is only used in hot-seat matches, the cpu keeps its own colors
+type StoneProfiles struct {
+	styles [TotalPlayerCount]StoneStyle
+}
+
+type stoneStyleEntry struct {
+	Palette string `json:"palette"`
+	Ring    string `json:"ring"`
+	Emblem  string `json:"emblem"`
+}
+
+type stonesFile struct {
+	Profiles map[string]stoneStyleEntry `json:"profiles"`
+}
+
+// the keys of the profiles in the file
+var stoneProfileKeys = [TotalPlayerCount]string{
+	PlayerOne: "p1",
+	PlayerTwo: "p2",
+}
+
+func defaultStoneProfiles() StoneProfiles {
+	return StoneProfiles{
+		styles: [TotalPlayerCount]StoneStyle{
+			PlayerOne: {palette: 0},
+			PlayerTwo: {palette: 1},
+		},
+	}
+}
+
+var stoneProfiles = defaultStoneProfiles()
+
+func indexOfKey(options []StyleOption, key string) int {
+	for i, option := range options {
+		if option.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func paletteOptions() []StyleOption {
+	options := []StyleOption{}
+	for _, palette := range PaletteList {
+		options = append(options, palette.StyleOption)
+	}
+	return options
+}
+
+func (profiles *StoneProfiles) load() {
+	file := stonesFile{}
+	if err := loadJSON(stonesFileName, &file); err != nil {
+		rl.TraceLog(rl.LogInfo, "stone styles could not be loaded: %v", err)
+		return
+	}
+
+	for player, key := range stoneProfileKeys {
+		entry, ok := file.Profiles[key]
+		if !ok {
+			continue
+		}
+
+		// unknown names, say from a newer version, keep the defaults
+		style := &profiles.styles[player]
+		if ix := indexOfKey(paletteOptions(), entry.Palette); ix != -1 {
+			style.palette = ix
+		}
+		if ix := indexOfKey(RingStyleList[:], entry.Ring); ix != -1 {
+			style.ring = RingStyle(ix)
+		}
+		if ix := indexOfKey(EmblemList[:], entry.Emblem); ix != -1 {
+			style.emblem = Emblem(ix)
+		}
+	}
+}
+
+func (profiles *StoneProfiles) save() {
+	file := stonesFile{Profiles: map[string]stoneStyleEntry{}}
+	for player, key := range stoneProfileKeys {
+		style := profiles.styles[player]
+		file.Profiles[key] = stoneStyleEntry{
+			Palette: PaletteList[style.palette].key,
+			Ring:    RingStyleList[style.ring].key,
+			Emblem:  EmblemList[style.emblem].key,
+		}
+	}
+
+	if err := saveJSON(stonesFileName, file); err != nil {
+		rl.TraceLog(rl.LogWarning, "stone styles could not be saved: %v", err)
+	}
+}
+
+// applyTo - the colors, the ring and the emblem of the style, anything locked falls back to the plain look
+func (style StoneStyle) applyTo(settings PlayerSettings) PlayerSettings {
+	if palette := PaletteList[style.palette]; palette.isUnlocked() {
+		settings.primaryColor = palette.palette.primaryColor
+		settings.outerRingColor = palette.palette.outerRingColor
+		settings.lifeColor = palette.palette.lifeColor
+		settings.rocketColor = palette.palette.rocketColor
+	}
+
+	settings.ringStyle = SolidRing
+	if RingStyleList[style.ring].isUnlocked() {
+		settings.ringStyle = style.ring
+	}
+
+	settings.emblem = NoEmblem
+	if EmblemList[style.emblem].isUnlocked() {
+		settings.emblem = style.emblem
+	}
+
+	return settings
+}
+
+// applyToPlayers - player one is always the first profile, player two only when it's a person
+func (profiles *StoneProfiles) applyToPlayers(playerSettings [TotalPlayerCount]PlayerSettings) [TotalPlayerCount]PlayerSettings {
+	playerSettings[PlayerOne] = profiles.styles[PlayerOne].applyTo(playerSettings[PlayerOne])
+
+	if !playerSettings[PlayerTwo].isCpu {
+		playerSettings[PlayerTwo] = profiles.styles[PlayerTwo].applyTo(playerSettings[PlayerTwo])
+	}
+
+	// the two sides have to be told apart, player two gives up the colors when they're the same
+	for ix := 0; ix < len(PaletteList) && playerSettings[PlayerTwo].primaryColor == playerSettings[PlayerOne].primaryColor; ix++ {
+		style := StoneStyle{palette: ix, ring: playerSettings[PlayerTwo].ringStyle, emblem: playerSettings[PlayerTwo].emblem}
+		playerSettings[PlayerTwo] = style.applyTo(playerSettings[PlayerTwo])
+	}
+
+	return playerSettings
+}
+
+// drawStoneFace - the body, the ring, the emblem and the life of a stone
+func drawStoneFace(pos rl.Vector2, radius, life float32, settings PlayerSettings) {
+	rl.DrawCircleV(pos, radius, settings.primaryColor)
+
+	drawStoneRing(pos, radius, settings.ringStyle, settings.outerRingColor)
+	drawStoneEmblem(pos, radius*0.45, settings.emblem, settings.outerRingColor)
+
+	rl.DrawRing(
+		pos,
+		radius*0.5,
+		radius*0.8,
+		0.0,
+		360.0*life/100,
+		0,
+		settings.lifeColor,
+	)
+}
+
+// drawStoneRing - the outer/border ring
+func drawStoneRing(pos rl.Vector2, radius float32, style RingStyle, color rl.Color) {
+	switch style {
+	case DoubleRing:
+		rl.DrawRing(pos, radius*0.8, radius*0.86, 0, 360, 0, color)
+		rl.DrawRing(pos, radius*0.93, radius*1.01, 0, 360, 0, color)
+	case DashedRing:
+		const dashes = 12
+		for i := range dashes {
+			start := float32(i) * 360 / dashes
+			rl.DrawRing(pos, radius*0.8, radius*1.01, start, start+360/dashes*0.6, 0, color)
+		}
+	case CrownRing:
+		rl.DrawRing(pos, radius*0.8, radius*0.92, 0, 360, 0, color)
+		// the crimps
+		const crimps = 21
+		for i := range crimps {
+			angle := float64(i) * 2 * math.Pi / crimps
+			crimp := rl.NewVector2(pos.X+radius*0.93*float32(math.Cos(angle)), pos.Y+radius*0.93*float32(math.Sin(angle)))
+			rl.DrawCircleV(crimp, radius*0.1, color)
+		}
+	default:
+		rl.DrawRing(pos, radius*0.8, radius*1.01, 0, 360, 0, color)
+	}
+}
+
+// drawStoneEmblem - drawn in the middle of the stone, inside the life ring
+func drawStoneEmblem(pos rl.Vector2, radius float32, emblem Emblem, color rl.Color) {
+	switch emblem {
+	case DotEmblem:
+		rl.DrawCircleV(pos, radius*0.4, color)
+	case SliceEmblem:
+		for i := range 6 {
+			angle := float64(i) * math.Pi / 3
+			end := rl.NewVector2(pos.X+radius*float32(math.Cos(angle)), pos.Y+radius*float32(math.Sin(angle)))
+			rl.DrawLineEx(pos, end, radius*0.12, color)
+		}
+	case WaveEmblem:
+		const steps = 12
+		previous := rl.NewVector2(pos.X-radius, pos.Y)
+		for i := 1; i <= steps; i++ {
+			t := float32(i) / steps
+			point := rl.NewVector2(pos.X-radius+2*radius*t, pos.Y+radius*0.35*float32(math.Sin(float64(t)*2*math.Pi)))
+			rl.DrawLineEx(previous, point, radius*0.2, color)
+			previous = point
+		}
+	case StarEmblem:
+		rl.DrawPoly(pos, 3, radius*0.8, -90, color)
+		rl.DrawPoly(pos, 3, radius*0.8, 90, color)
+	}
+}
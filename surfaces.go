@@ -0,0 +1,169 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type SurfaceKind = uint8
+
+const (
+	IceSurface        SurfaceKind = iota // the stones barely slow down on it
+	SandSurface       SurfaceKind = iota // the stones stop quickly on it
+	BoostPad          SurfaceKind = iota // speeds the stones up along its direction
+	ConveyorStrip     SurfaceKind = iota // drags the stones along its direction
+	TotalSurfaceKinds SurfaceKind = iota
+)
+
+// SurfaceInfo - how a surface changes the movement of the stones over it
+type SurfaceInfo struct {
+	damping float32 // replaces VelocityDampingFactor
+	push    float32 // of MaxPushVelocityAllowed, added every frame along the direction of the zone
+	reach   float32 // how much further a shot goes over it, for the cpu
+	color   rl.Color
+}
+
+var SurfaceList = [TotalSurfaceKinds]SurfaceInfo{
+	IceSurface:    {damping: 0.996, push: 0, reach: 1.6, color: rl.NewColor(200, 235, 250, 255)},
+	SandSurface:   {damping: 0.95, push: 0, reach: 0.4, color: rl.NewColor(222, 196, 140, 255)},
+	BoostPad:      {damping: 0.987, push: 0.02, reach: 1.5, color: rl.NewColor(250, 160, 50, 255)},
+	ConveyorStrip: {damping: 0.987, push: 0.004, reach: 1, color: rl.NewColor(110, 115, 125, 255)},
+}
+
+// SurfaceZone - a part of the field with a different surface.
+// the area is in fractions of the canvas so the levels can be declared before the canvas exists
+type SurfaceZone struct {
+	kind      SurfaceKind
+	area      rl.Rectangle
+	direction rl.Vector2 // boost pads and conveyors only
+}
+
+func (zone SurfaceZone) rect() rl.Rectangle {
+	return rl.NewRectangle(
+		zone.area.X*CanvasWidth,
+		zone.area.Y*CanvasHeight,
+		zone.area.Width*CanvasWidth,
+		zone.area.Height*CanvasHeight,
+	)
+}
+
+// surfaceAt - the zone under the point, the last one listed wins where they overlap
+func (level *Level) surfaceAt(pos rl.Vector2) (SurfaceZone, bool) {
+	zones := level.levelSettings.surfaces
+	for i := len(zones) - 1; i >= 0; i-- {
+		if rl.CheckCollisionPointRec(pos, zones[i].rect()) {
+			return zones[i], true
+		}
+	}
+	return SurfaceZone{}, false
+}
+
+// applySurface - the surface under the stone pushes it and slows it down.
+// only the moving stones are affected, so the turns still end with the stones at rest
+func (level *Level) applySurface(s *Stone) {
+	zone, ok := level.surfaceAt(s.pos)
+	if !ok || rl.Vector2Length(s.velocity) == 0 {
+		calcVelocity(s, VelocityDampingFactor)
+		return
+	}
+
+	info := SurfaceList[zone.kind]
+	if info.push > 0 {
+		push := rl.Vector2Scale(rl.Vector2Normalize(zone.direction), info.push*MaxPushVelocityAllowed)
+		s.velocity = rl.Vector2ClampValue(rl.Vector2Add(s.velocity, push), 0, MaxPushVelocityAllowed*1.5)
+	}
+
+	calcVelocity(s, info.damping)
+}
+
+// pathReach - how much further than usual a stone goes along the path, sampled over the surfaces it crosses
+func (level *Level) pathReach(from, to rl.Vector2) float32 {
+	if len(level.levelSettings.surfaces) == 0 {
+		return 1
+	}
+
+	const samples = 16
+	direction := rl.Vector2Normalize(rl.Vector2Subtract(to, from))
+	total := float32(0)
+
+	for i := range samples {
+		point := rl.Vector2Lerp(from, to, (float32(i)+0.5)/samples)
+		zone, ok := level.surfaceAt(point)
+		if !ok {
+			total += 1
+			continue
+		}
+
+		reach := SurfaceList[zone.kind].reach
+		if zone.kind == BoostPad || zone.kind == ConveyorStrip {
+			// with the push helps, against it hurts
+			along := rl.Vector2DotProduct(direction, rl.Vector2Normalize(zone.direction))
+			reach = 1 + (reach-1)*along + 0.3*along
+		}
+		total += max(reach, 0.2)
+	}
+
+	return total / samples
+}
+
+// drawSurfaces - the zones are drawn as simple textures, below everything else on the field
+func (level *Level) drawSurfaces() {
+	time := float64(level.clock.seconds())
+	if config.Accessibility.ReduceMotion {
+		time = 0
+	}
+
+	for _, zone := range level.levelSettings.surfaces {
+		rect := zone.rect()
+		info := SurfaceList[zone.kind]
+		rl.DrawRectangleRec(rect, rl.ColorAlpha(info.color, 0.55))
+
+		rl.BeginScissorMode(int32(rect.X), int32(rect.Y), int32(rect.Width), int32(rect.Height))
+
+		switch zone.kind {
+		case IceSurface:
+			// diagonal glints
+			step := StoneRadius * 0.9
+			for x := rect.X - rect.Height; x < rect.X+rect.Width; x += step {
+				rl.DrawLineEx(
+					rl.NewVector2(x, rect.Y+rect.Height),
+					rl.NewVector2(x+rect.Height, rect.Y),
+					StoneRadius*0.04,
+					dimWhite(120),
+				)
+			}
+		case SandSurface:
+			// grains, placed the same way every frame
+			step := StoneRadius * 0.35
+			for y := rect.Y; y < rect.Y+rect.Height; y += step {
+				for x := rect.X; x < rect.X+rect.Width; x += step {
+					jitter := float32(math.Sin(float64(x*12.9898+y*78.233))) * step * 0.4
+					rl.DrawCircleV(rl.NewVector2(x+jitter, y-jitter), StoneRadius*0.04, rl.NewColor(150, 120, 70, 160))
+				}
+			}
+		case BoostPad, ConveyorStrip:
+			// chevrons pointing the way, the conveyor's slide along it
+			direction := rl.Vector2Normalize(zone.direction)
+			side := rl.NewVector2(-direction.Y, direction.X)
+			step := StoneRadius * 0.8
+			offset := float32(0)
+			if zone.kind == ConveyorStrip {
+				offset = float32(math.Mod(time*float64(step), float64(step)))
+			}
+
+			center := rl.NewVector2(rect.X+rect.Width/2, rect.Y+rect.Height/2)
+			length := rl.Vector2Length(rl.NewVector2(rect.Width, rect.Height))
+			size := min(rect.Width, rect.Height) * 0.3
+
+			for d := -length / 2; d < length/2; d += step {
+				tip := rl.Vector2Add(center, rl.Vector2Scale(direction, d+offset))
+				back := rl.Vector2Subtract(tip, rl.Vector2Scale(direction, size))
+				rl.DrawLineEx(tip, rl.Vector2Add(back, rl.Vector2Scale(side, size)), StoneRadius*0.06, dimWhite(150))
+				rl.DrawLineEx(tip, rl.Vector2Subtract(back, rl.Vector2Scale(side, size)), StoneRadius*0.06, dimWhite(150))
+			}
+		}
+
+		rl.EndScissorMode()
+	}
+}
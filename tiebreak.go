@@ -0,0 +1,156 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type Tiebreak = uint8
+
+const (
+	TiebreakByStones         Tiebreak = iota // more stones on the field
+	TiebreakByLife           Tiebreak = iota // more life left in total
+	TiebreakByBorderDistance Tiebreak = iota // stones further from the edge, the ones close to it are easy to knock off
+	TiebreakByTurn           Tiebreak = iota // the one whose turn it is loses, it always decides
+)
+
+// the chain is tried in order when the time runs out, the first one telling the players apart decides
+var defaultTiebreaks = []Tiebreak{TiebreakByStones, TiebreakByLife, TiebreakByBorderDistance}
+
+var tiebreakFinishReasons = map[Tiebreak]FinishReason{
+	TiebreakByStones:         FinishedByStoneCount,
+	TiebreakByLife:           FinishedByLife,
+	TiebreakByBorderDistance: FinishedByBorderDistance,
+	TiebreakByTurn:           FinishedByTurn,
+}
+
+// edgeDistance - how far the center of the stone is from the closest wall or edge of the field
+func (level *Level) edgeDistance(s *Stone) float32 {
+	return level.playArea().distanceToWall(s.pos)
+}
+
+// tiebreakTotals - what each player has for the tiebreak, more is better
+func (level *Level) tiebreakTotals(tiebreak Tiebreak) [TotalPlayerCount]float32 {
+	totals := [TotalPlayerCount]float32{}
+
+	if tiebreak == TiebreakByTurn {
+		totals[level.playerTurn] = -1
+		return totals
+	}
+
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if stone.isDead {
+			continue
+		}
+
+		switch tiebreak {
+		case TiebreakByStones:
+			totals[stone.playerId] += 1
+		case TiebreakByLife:
+			totals[stone.playerId] += stone.life
+		case TiebreakByBorderDistance:
+			totals[stone.playerId] += level.edgeDistance(stone)
+		}
+	}
+
+	return totals
+}
+
+// tiebreakTolerance - the difference that still counts as a tie, a few pixels closer to the edge shouldn't decide a match
+func tiebreakTolerance(tiebreak Tiebreak) float32 {
+	if tiebreak == TiebreakByBorderDistance {
+		return StoneRadius * 0.1
+	}
+	return 0
+}
+
+// resolveTimeUp - goes down the tiebreak chain, and starts the sudden death when all of it is tied
+func (level *Level) resolveTimeUp() {
+	for _, tiebreak := range level.levelSettings.tiebreaks {
+		totals := level.tiebreakTotals(tiebreak)
+		difference := totals[PlayerOne] - totals[PlayerTwo]
+
+		if float32(math.Abs(float64(difference))) <= tiebreakTolerance(tiebreak) {
+			continue
+		}
+
+		if difference > 0 {
+			level.score[PlayerTwo] = 0
+		} else {
+			level.score[PlayerOne] = 0
+		}
+		level.finishReason = tiebreakFinishReasons[tiebreak]
+		return
+	}
+
+	if level.levelSettings.suddenDeath {
+		level.overtime = true
+		level.overtimeScore = level.score
+		return
+	}
+
+	// nothing else left to decide it with
+	level.score[level.playerTurn] = 0
+	level.finishReason = FinishedByTurn
+}
+
+// checkOvertime - the first player to lose a stone in the sudden death loses the match.
+// when both lose one with the same shot, it goes on
+func (level *Level) checkOvertime() {
+	lostOne := level.score[PlayerOne] < level.overtimeScore[PlayerOne]
+	lostTwo := level.score[PlayerTwo] < level.overtimeScore[PlayerTwo]
+
+	if lostOne && lostTwo {
+		level.overtimeScore = level.score
+		return
+	}
+
+	if lostOne {
+		level.score[PlayerOne] = 0
+		level.finishReason = FinishedBySuddenDeath
+	} else if lostTwo {
+		level.score[PlayerTwo] = 0
+		level.finishReason = FinishedBySuddenDeath
+	}
+}
+
+// drawOvertime - takes the place of the timer once the sudden death starts
+func (level *Level) drawOvertime(screenWidth, screenHeight float32, backgroundColor rl.Color) {
+	text := "SUDDEN DEATH"
+	fontSize := FontSize / 6
+	spacing := FontSize / 60
+	measured := rl.MeasureTextEx(rl.GetFontDefault(), text, fontSize, spacing)
+
+	boxSize := rl.NewVector2(measured.X*1.2, measured.Y*1.4)
+	box := rl.NewRectangle((screenWidth-boxSize.X)/2, (screenHeight-boxSize.Y)/2, boxSize.X, boxSize.Y)
+
+	alpha := float32(1)
+	if !config.Accessibility.ReduceMotion {
+		alpha = 0.7 + 0.3*float32(math.Sin(float64(level.clock.seconds())*5))
+	}
+	color := rl.ColorAlpha(rl.NewColor(230, 41, 55, 255), alpha)
+
+	rl.DrawRectangleRec(box, backgroundColor)
+	rl.DrawRectangleLinesEx(box, 10, color)
+	rl.DrawTextEx(
+		rl.GetFontDefault(),
+		text,
+		rl.NewVector2((screenWidth-measured.X)/2, (screenHeight-measured.Y)/2),
+		fontSize,
+		spacing,
+		color,
+	)
+
+	hint := "the next stone lost decides the match"
+	hintSize := rl.MeasureTextEx(rl.GetFontDefault(), hint, fontSize/2, spacing/2)
+	rl.DrawTextEx(
+		rl.GetFontDefault(),
+		hint,
+		rl.NewVector2((screenWidth-hintSize.X)/2, box.Y+box.Height+hintSize.Y*0.5),
+		fontSize/2,
+		spacing/2,
+		color,
+	)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const noTouch int32 = -1
+
+// TouchInput - the fingers on the screen this frame and the last one.
+// raylib also turns the first finger into the mouse, so the touch code only
+// adds what the mouse can't do: bigger targets and telling the fingers apart
+type TouchInput struct {
+	count         int32
+	previousCount int32
+	ids           []int32
+	positions     []rl.Vector2 // on the canvas, raylib reports them in window pixels
+	lastPosition  rl.Vector2
+	inUse         bool // the last thing the player used was a finger
+}
+
+var touchInput = TouchInput{}
+
+func (touch *TouchInput) update(window *Window) {
+	touch.previousCount = touch.count
+	touch.count = rl.GetTouchPointCount()
+
+	touch.ids = touch.ids[:0]
+	touch.positions = touch.positions[:0]
+	for i := range touch.count {
+		touch.ids = append(touch.ids, rl.GetTouchPointId(i))
+		touch.positions = append(touch.positions, window.toCanvas(rl.GetTouchPosition(i)))
+	}
+
+	if touch.count > 0 {
+		touch.inUse = true
+		touch.lastPosition = touch.positions[0]
+	} else if touch.previousCount == 0 && rl.Vector2Length(rl.GetMouseDelta()) > 0 {
+		// the emulated mouse moves with the finger, so only a move without any finger is a real mouse
+		touch.inUse = false
+	}
+}
+
+// position - where the finger with the given id is, if it's still on the screen
+func (touch *TouchInput) position(id int32) (rl.Vector2, bool) {
+	for i, touchId := range touch.ids {
+		if touchId == id {
+			return touch.positions[i], true
+		}
+	}
+	return rl.NewVector2(0, 0), false
+}
+
+// tapped - the last finger was lifted this frame
+func (touch *TouchInput) tapped() bool {
+	return touch.previousCount > 0 && touch.count == 0
+}
+
+// hitArea - the text buttons are thin, fingers get some extra room around them
+func (touch *TouchInput) hitArea(rectangle rl.Rectangle) rl.Rectangle {
+	if !touch.inUse {
+		return rectangle
+	}
+	padding := TouchMinGrabRadius * 0.5
+	return rl.NewRectangle(
+		rectangle.X-padding,
+		rectangle.Y-padding,
+		rectangle.Width+padding*2,
+		rectangle.Height+padding*2,
+	)
+}
+
+// isOver - whether the mouse, or the finger that was just on the screen, is over the button
+func (touch *TouchInput) isOver(rectangle rl.Rectangle) bool {
+	position := rl.GetMousePosition()
+	if touch.inUse {
+		position = touch.lastPosition
+	}
+	return rl.CheckCollisionPointRec(position, touch.hitArea(rectangle))
+}
+
+// grabRadius - fingers are much less precise than a cursor, small stones get a bigger hit area
+func grabRadius(s *Stone) float32 {
+	if touchInput.inUse {
+		return max(s.radius, TouchMinGrabRadius)
+	}
+	return s.radius
+}
+
+// handleTouchMove - the finger that grabbed a stone is the only one that can aim it,
+// the other fingers are ignored until it's lifted
+func (level *Level) handleTouchMove() {
+	if level.touchId == noTouch {
+		if touchInput.count == 0 || !level.stonesAreStill || level.selectedStone != nil {
+			return
+		}
+
+		for i, position := range touchInput.positions {
+			if stone := level.stoneUnder(position); stone != nil {
+				level.cancelAim()
+				level.selectedStone = stone
+				level.action = StoneAimed
+				level.touchId = touchInput.ids[i]
+				level.setAimVectorStart(position)
+				return
+			}
+		}
+		return
+	}
+
+	if position, ok := touchInput.position(level.touchId); ok {
+		level.setAimVectorStart(position)
+		return
+	}
+
+	// the aiming finger was lifted, aimVectorStart is where it was last seen
+	level.touchId = noTouch
+	if level.action != StoneAimed {
+		return
+	}
+
+	if rl.CheckCollisionPointCircle(level.aimVectorStart, level.selectedStone.pos, StoneSelectionCancelCircleRadius) {
+		level.cancelAim()
+	} else {
+		level.action = StoneHit
+	}
+}
+
+// stoneUnder - the closest stone of the current player within grabbing distance
+func (level *Level) stoneUnder(position rl.Vector2) *Stone {
+	var closest *Stone
+	closestDistance := float32(0)
+
+	for i := range level.stones {
+		stone := &level.stones[i]
+		if !stone.canBePlayed() || stone.playerId != level.playerTurn {
+			continue
+		}
+
+		distance := rl.Vector2Distance(position, stone.pos)
+		if distance > grabRadius(stone) {
+			continue
+		}
+
+		if closest == nil || distance < closestDistance {
+			closest = stone
+			closestDistance = distance
+		}
+	}
+
+	return closest
+}
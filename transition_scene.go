@@ -11,7 +11,9 @@ type SceneTransition struct {
 	winner           Player
 	message          buttonRectangle
 	buttonRectangles []buttonRectangle
+	focus            menuFocus
 	data             *Level
+	summary          MatchSummary
 }
 
 func NewSceneTransition() SceneTransition {
@@ -23,80 +25,81 @@ func (scene *SceneTransition) GetId() SceneId {
 }
 
 func (scene *SceneTransition) Init(data any, window *Window) {
-	scene.buttonRectangles = nil
-
 	scene.data = data.(*Level)
 	scene.nextSceneId = scene.GetId()
 
-	scene.winner = PlayerOne
-	if scene.data.score[PlayerOne] == 0 {
-		scene.winner = PlayerTwo
-	}
-
-	{
-		screenWidth, screenHeight := window.GetScreenDimensions()
+	scene.winner = scene.data.winner()
 
-		whoWon := fmt.Sprintf("%s won!", scene.data.playerSettings[scene.winner].label)
-		offsetX := float32(0.0)
-		if scene.winner == PlayerOne {
-			offsetX = screenWidth / 2
-		}
-		measuredSize := rl.MeasureTextEx(rl.GetFontDefault(), whoWon, FontSize/4, 10)
-		w := offsetX + (screenWidth/2-measuredSize.X)/2
-		h := (screenHeight - measuredSize.Y) / 2.5
-
-		scene.message = buttonRectangle{
-			text:      whoWon,
-			rectangle: rl.NewRectangle(w, h, measuredSize.X, measuredSize.Y),
-			fontSize:  FontSize / 4,
-		}
+	scene.summary = summarizeMatch(&scene.data.matchLog, scene.data.clock.seconds())
 
-		h = h + measuredSize.Y
+	// the message and the buttons in the winner's half
+	scene.buttonRectangles = nil
 
-		if scene.winner == PlayerOne { // TODO: we probably should not hardcode this cause it locks the player to the left half of the screen
-			next := rl.MeasureTextEx(rl.GetFontDefault(), "next", FontSize/7, 10)
+	screenWidth, screenHeight := window.GetScreenDimensions()
 
-			w = offsetX + (screenWidth/2-next.X)/2
-			h = h + next.Y*1.2
+	whoWon := fmt.Sprintf("%s won!", scene.data.playerSettings[scene.winner].label)
+	offsetX := float32(0.0)
+	if scene.winner == PlayerOne {
+		offsetX = screenWidth / 2
+	}
+	measuredSize := rl.MeasureTextEx(rl.GetFontDefault(), whoWon, FontSize/4, 10)
+	w := offsetX + (screenWidth/2-measuredSize.X)/2
+	h := (screenHeight - measuredSize.Y) / 2.5
+
+	scene.message = buttonRectangle{
+		text:      whoWon,
+		rectangle: rl.NewRectangle(w, h, measuredSize.X, measuredSize.Y),
+		fontSize:  FontSize / 4,
+	}
 
-			targetScene := LevelProgression[scene.data.levelSettings.sceneId]
+	h = h + measuredSize.Y
 
-			scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
-				text:        "next",
-				rectangle:   rl.NewRectangle(w, h, next.X, next.Y),
-				fontSize:    FontSize / 7,
-				targetScene: targetScene,
-			})
-		}
+	if scene.winner == PlayerOne { // TODO: we probably should not hardcode this cause it locks the player to the left half of the screen
+		next := rl.MeasureTextEx(rl.GetFontDefault(), "next", FontSize/7, 10)
 
-		restart := rl.MeasureTextEx(rl.GetFontDefault(), "restart", FontSize/7, 10)
+		w = offsetX + (screenWidth/2-next.X)/2
+		h = h + next.Y*1.2
 
-		w = offsetX + (screenWidth/2-restart.X)/2
-		h = h + restart.Y*1.2
+		targetScene := LevelProgression[scene.data.levelSettings.sceneId]
 
 		scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
-			text:        "restart",
-			rectangle:   rl.NewRectangle(w, h, restart.X, restart.Y),
-			fontSize:    FontSize / 7,
-			targetScene: scene.data.levelSettings.sceneId,
+			text:         "next",
+			rectangle:    rl.NewRectangle(w, h, next.X, next.Y),
+			fontSize:     FontSize / 7,
+			targetScene:  targetScene,
+			interactable: true,
 		})
+	}
 
-		mainMenu := rl.MeasureTextEx(rl.GetFontDefault(), "main menu", FontSize/7, 10) // TODO: should spacing be static????
+	restart := rl.MeasureTextEx(rl.GetFontDefault(), "restart", FontSize/7, 10)
 
-		w = offsetX + (screenWidth/2-mainMenu.X)/2
-		h = h + mainMenu.Y*1.2
+	w = offsetX + (screenWidth/2-restart.X)/2
+	h = h + restart.Y*1.2
 
-		scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
-			text:        "main menu",
-			rectangle:   rl.NewRectangle(w, h, mainMenu.X, mainMenu.Y),
-			fontSize:    FontSize / 7,
-			targetScene: Main,
-		})
-	}
+	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
+		text:         "restart",
+		rectangle:    rl.NewRectangle(w, h, restart.X, restart.Y),
+		fontSize:     FontSize / 7,
+		targetScene:  scene.data.levelSettings.sceneId,
+		interactable: true,
+	})
+
+	mainMenu := rl.MeasureTextEx(rl.GetFontDefault(), "main menu", FontSize/7, 10) // TODO: should spacing be static????
+
+	w = offsetX + (screenWidth/2-mainMenu.X)/2
+	h = h + mainMenu.Y*1.2
+
+	scene.buttonRectangles = append(scene.buttonRectangles, buttonRectangle{
+		text:         "main menu",
+		rectangle:    rl.NewRectangle(w, h, mainMenu.X, mainMenu.Y),
+		fontSize:     FontSize / 7,
+		targetScene:  Main,
+		interactable: true,
+	})
 }
 
 func (scene *SceneTransition) HandleUserInput(window *Window) {
-	if rl.IsMouseButtonReleased(rl.MouseButtonLeft) {
+	if scene.focus.activated() {
 		for _, buttonConfig := range scene.buttonRectangles {
 			if buttonConfig.active {
 				scene.nextSceneId = buttonConfig.targetScene
@@ -114,11 +117,7 @@ func (scene *SceneTransition) Update(window *Window) (SceneId, any) {
 		scene.data.allParticles[i].update()
 	}
 
-	mousePosition := rl.GetMousePosition()
-
-	for bi, buttonConfig := range scene.buttonRectangles {
-		scene.buttonRectangles[bi].active = rl.CheckCollisionPointRec(mousePosition, buttonConfig.rectangle)
-	}
+	scene.focus.update(scene.buttonRectangles)
 
 	return scene.nextSceneId, nil
 }
@@ -169,6 +168,8 @@ func (scene *SceneTransition) Draw(window *Window) {
 		)
 	}
 
+	scene.drawSummary(window)
+
 	{
 		// draw shards
 		// TODO: maybe the particles too?
@@ -178,6 +179,117 @@ func (scene *SceneTransition) Draw(window *Window) {
 	}
 }
 
+// drawSummary - the post-match panel on the half of the screen that does not have the buttons
+func (scene *SceneTransition) drawSummary(window *Window) {
+	screenWidth, screenHeight := window.GetScreenDimensions()
+
+	offsetX := screenWidth / 2
+	if scene.winner == PlayerOne {
+		offsetX = 0
+	}
+
+	panel := rl.NewRectangle(offsetX+screenWidth*0.04, screenHeight*0.08, screenWidth/2-screenWidth*0.08, screenHeight*0.84)
+
+	rl.DrawRectangleRec(panel, scene.data.levelSettings.backgroundColor)
+	rl.DrawRectangleLinesEx(panel, 10, dimWhite(125))
+
+	summary := scene.summary
+	players := scene.data.playerSettings
+	defaultFont := rl.GetFontDefault()
+	textColor := dimWhite(120)
+
+	padding := panel.Width * 0.05
+	x := panel.X + padding
+	y := panel.Y + padding
+	innerWidth := panel.Width - 2*padding
+
+	titleSize := FontSize / 8
+	rl.DrawTextEx(defaultFont, "match summary", rl.NewVector2(x, y), titleSize, titleSize/10, dimWhite(200))
+	y += titleSize * 1.3
+
+	textSize := FontSize / 14
+	lineHeight := textSize * 1.3
+
+	perPlayer := func(values [TotalPlayerCount]int) string {
+		return fmt.Sprintf("%s %d   %s %d", players[PlayerOne].label, values[PlayerOne], players[PlayerTwo].label, values[PlayerTwo])
+	}
+
+	bestShot := "-"
+	if summary.bestShot != -1 {
+		shot := summary.shots[summary.bestShot]
+		bestShot = fmt.Sprintf(
+			"%s #%d: %d off, %.0f dmg",
+			players[shot.shooter].label,
+			shot.index+1,
+			shot.enemiesKnocked,
+			shot.damageDealt,
+		)
+	}
+
+	rows := [][2]string{
+		{"time", formatDuration(summary.duration)},
+		{"shots", perPlayer(summary.shotCount)},
+		{"own goals", perPlayer(summary.ownGoals)},
+		{"best shot", bestShot},
+	}
+
+	for _, row := range rows {
+		rl.DrawTextEx(defaultFont, row[0], rl.NewVector2(x, y), textSize, textSize/10, textColor)
+		rl.DrawTextEx(defaultFont, row[1], rl.NewVector2(x+innerWidth*0.35, y), textSize, textSize/10, textColor)
+		y += lineHeight
+	}
+
+	y += lineHeight * 0.5
+	chartHeight := (panel.Y + panel.Height - padding - y - 2*lineHeight) / 2
+
+	// damage dealt per shot - one bar per shot in the shooter's color
+	rl.DrawTextEx(defaultFont, "damage per shot", rl.NewVector2(x, y), textSize, textSize/10, textColor)
+	y += lineHeight
+
+	maxDamage := float32(1)
+	for _, shot := range summary.shots {
+		maxDamage = max(maxDamage, shot.damageDealt)
+	}
+
+	if shotCount := len(summary.shots); shotCount > 0 {
+		barWidth := innerWidth / float32(shotCount)
+		for i, shot := range summary.shots {
+			barHeight := chartHeight * shot.damageDealt / maxDamage
+			bar := rl.NewRectangle(x+float32(i)*barWidth, y+chartHeight-barHeight, barWidth*0.8, barHeight)
+			rl.DrawRectangleRec(bar, scene.data.lookOf(shot.shooter).primaryColor)
+			if i == summary.bestShot {
+				rl.DrawRectangleLinesEx(bar, 3, dimWhite(200))
+			}
+		}
+	}
+	rl.DrawLineEx(rl.NewVector2(x, y+chartHeight), rl.NewVector2(x+innerWidth, y+chartHeight), 2, dimWhite(60))
+	y += chartHeight + lineHeight*0.5
+
+	// total stone life of each side over the match
+	rl.DrawTextEx(defaultFont, "life", rl.NewVector2(x, y), textSize, textSize/10, textColor)
+	y += lineHeight
+
+	if summary.duration > 0 && summary.maxLife > 0 {
+		for p := range TotalPlayerCount {
+			points := make([]rl.Vector2, 0, len(summary.timeline)*2)
+			for si, sample := range summary.timeline {
+				px := x + innerWidth*sample.time/summary.duration
+				py := y + chartHeight*(1-sample.life[p]/summary.maxLife)
+				if si > 0 {
+					// a step chart: the life stays the same until the next event
+					points = append(points, rl.NewVector2(px, points[len(points)-1].Y))
+				}
+				points = append(points, rl.NewVector2(px, py))
+			}
+
+			for i := 1; i < len(points); i++ {
+				rl.DrawLineEx(points[i-1], points[i], 4, scene.data.lookOf(p).primaryColor)
+			}
+		}
+	}
+	rl.DrawLineEx(rl.NewVector2(x, y+chartHeight), rl.NewVector2(x+innerWidth, y+chartHeight), 2, dimWhite(60))
+}
+
 func (scene *SceneTransition) Teardown(window *Window) {
 
 }
@@ -0,0 +1,169 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type FoulPenalty = uint8
+
+const (
+	NoFoulPenalty       FoulPenalty = iota
+	LifeFoulPenalty     FoulPenalty = iota // the shot stone loses a share of the starting life
+	SkipTurnFoulPenalty FoulPenalty = iota // the next turn of the player goes to the opponent
+)
+
+const (
+	FoulLifeShare    = 0.2 // of the starting life
+	TurnNoticeLength = 2   // seconds the foul or the extra turn is shown for
+)
+
+// TurnRules - what a shot earns or costs, judged once the stones are still.
+// the turns simply alternate when it's all off
+type TurnRules struct {
+	extraTurnOnKnockout bool // knocking off an enemy stone without losing one of your own shoots again
+	foulOnOwnContact    bool // the shot stone touching one of your own stones first
+	foulOnOwnKnockout   bool // knocking off one of your own stones
+	penalty             FoulPenalty
+}
+
+// ShotOutcome - what the last shot did, read from the match log
+type ShotOutcome struct {
+	shooter         Player
+	stoneId         uint8
+	enemiesKnocked  int
+	ownKnocked      int
+	firstContactOwn bool
+}
+
+func opponentOf(player Player) Player {
+	if player == PlayerOne {
+		return PlayerTwo
+	}
+	return PlayerOne
+}
+
+func (level *Level) stoneWithId(id uint8) *Stone {
+	for i := range level.stones {
+		if level.stones[i].id == id {
+			return &level.stones[i]
+		}
+	}
+	return nil
+}
+
+// lastShot - the outcome of the shot in progress, false before the first one
+func (level *Level) lastShot() (ShotOutcome, bool) {
+	outcome := ShotOutcome{}
+	fired := false
+	touched := false
+
+	for _, e := range level.matchLog.events {
+		if e.shot != level.matchLog.currentShot {
+			continue
+		}
+
+		switch e.kind {
+		case ShotFired:
+			outcome.shooter = e.playerId
+			outcome.stoneId = e.stoneId
+			fired = true
+		case StoneCollided:
+			if touched || (e.stoneId != outcome.stoneId && e.otherId != outcome.stoneId) {
+				continue
+			}
+			touched = true
+
+			otherId := e.otherId
+			if otherId == outcome.stoneId {
+				otherId = e.stoneId
+			}
+			if other := level.stoneWithId(otherId); other != nil {
+				outcome.firstContactOwn = other.playerId == outcome.shooter
+			}
+		case StoneDied:
+			if e.playerId == outcome.shooter {
+				outcome.ownKnocked++
+			} else {
+				outcome.enemiesKnocked++
+			}
+		}
+	}
+
+	return outcome, fired
+}
+
+// endTurn - judges the shot and hands the turn to whoever plays next
+func (level *Level) endTurn() {
+	shot, ok := level.lastShot()
+	if !ok {
+		return
+	}
+
+	rules := level.levelSettings.turnRules
+	next := opponentOf(shot.shooter)
+
+	foul := (rules.foulOnOwnContact && shot.firstContactOwn) || (rules.foulOnOwnKnockout && shot.ownKnocked > 0)
+	if foul {
+		level.penalize(shot)
+	} else if rules.extraTurnOnKnockout && shot.enemiesKnocked > 0 && shot.ownKnocked == 0 {
+		next = shot.shooter
+		level.showTurnNotice(shot.shooter, "extra turn")
+	}
+
+	// the turn the opponent lost to a foul
+	if next != shot.shooter && level.skipTurn[next] {
+		level.skipTurn[next] = false
+		next = shot.shooter
+	}
+
+	level.playerTurn = next
+}
+
+func (level *Level) penalize(shot ShotOutcome) {
+	switch level.levelSettings.turnRules.penalty {
+	case LifeFoulPenalty:
+		if stone := level.stoneWithId(shot.stoneId); stone != nil && !stone.isDead {
+			level.damageStone(stone, level.levelSettings.rules.startingLife*FoulLifeShare, nil)
+		}
+		level.showTurnNotice(shot.shooter, "foul - life lost")
+	case SkipTurnFoulPenalty:
+		level.skipTurn[shot.shooter] = true
+		level.showTurnNotice(shot.shooter, "foul - next turn skipped")
+	default:
+		level.showTurnNotice(shot.shooter, "foul")
+	}
+}
+
+func (level *Level) showTurnNotice(player Player, text string) {
+	level.turnNotice = text
+	level.turnNoticePlayer = player
+	level.turnNoticeAt = level.clock.seconds()
+}
+
+// drawTurnNotice - under the score of the player it's about, fading out
+func (level *Level) drawTurnNotice(screenWidth, screenHeight float32) {
+	age := level.clock.seconds() - level.turnNoticeAt
+	if level.turnNotice == "" || age > TurnNoticeLength {
+		return
+	}
+
+	// right under the label, laid out like drawScore
+	scoreSize := rl.MeasureTextEx(rl.GetFontDefault(), "00", FontSize, FontSize/10)
+	y := (screenHeight-scoreSize.Y)/2 + scoreSize.Y*0.8 + FontSize/3*1.2
+
+	fontSize := FontSize / 5
+	measured := rl.MeasureTextEx(rl.GetFontDefault(), level.turnNotice, fontSize, fontSize/10)
+	x := (screenWidth/2 - measured.X) / 2
+	if level.turnNoticePlayer == PlayerTwo {
+		x += screenWidth / 2
+	}
+
+	rl.DrawTextEx(
+		rl.GetFontDefault(),
+		level.turnNotice,
+		rl.NewVector2(x, y),
+		fontSize,
+		fontSize/10,
+		rl.ColorAlpha(dimWhite(200), 1-age/TurnNoticeLength),
+	)
+}
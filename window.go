@@ -6,49 +6,143 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
+// the game is laid out and simulated on a fixed canvas, the window only decides how big it is shown.
+// this way the balance is the same at 1280x720 and at 4K
+const CanvasWidth float32 = 2560
+const CanvasHeight float32 = 1440
+
+type DisplayMode = uint8
+
+const (
+	Windowed           DisplayMode = iota
+	BorderlessWindowed DisplayMode = iota
+	Fullscreen         DisplayMode = iota
+)
+
 type Window struct {
-	title           string
-	width           int32
-	height          int32
-	fullscreen      bool
-	musicVolume     float32
-	sfxVolume       float32
-	maxScreenWidth  int32
-	maxScreenHeight int32
+	title       string
+	width       int32 // the size of the window when it's windowed, kept while in the other modes
+	height      int32
+	displayMode DisplayMode
+	monitor     int
+	musicVolume float32
+	sfxVolume   float32
+	canvas      rl.RenderTexture2D
+	viewport    rl.Rectangle // where the canvas lands in the window
 }
 
-func (c *Window) GetScreenDimensions() (float32, float32) {
-	if c.fullscreen {
-		w := float32(rl.GetScreenWidth())
-		h := float32(rl.GetScreenHeight())
-		return w, h
-	} else {
-		return float32(c.width), float32(c.height)
+// monitorSize - the size of the monitor's current video mode
+func monitorSize(monitor int) (int32, int32) {
+	return int32(rl.GetMonitorWidth(monitor)), int32(rl.GetMonitorHeight(monitor))
+}
+
+// applyDisplay - puts the window on the monitor in the given mode, a windowed window is centered on it
+func (c *Window) applyDisplay(mode DisplayMode, monitor int, width, height int32) {
+	// the toggles only work from a plain window, so whatever mode we're in is left first
+	if rl.IsWindowFullscreen() {
+		rl.ToggleFullscreen()
+	}
+	if rl.IsWindowState(rl.FlagBorderlessWindowedMode) {
+		rl.ToggleBorderlessWindowed()
 	}
+
+	monitor = min(max(monitor, 0), rl.GetMonitorCount()-1)
+	monitorPosition := rl.GetMonitorPosition(monitor)
+	monitorWidth, monitorHeight := monitorSize(monitor)
+
+	switch mode {
+	case Windowed:
+		c.width = min(width, monitorWidth)
+		c.height = min(height, monitorHeight)
+		rl.SetWindowSize(int(c.width), int(c.height))
+		rl.SetWindowPosition(
+			int(monitorPosition.X)+int(monitorWidth-c.width)/2,
+			int(monitorPosition.Y)+int(monitorHeight-c.height)/2,
+		)
+	case BorderlessWindowed:
+		// borderless takes over the monitor the window is on
+		rl.SetWindowPosition(int(monitorPosition.X), int(monitorPosition.Y))
+		rl.ToggleBorderlessWindowed()
+	case Fullscreen:
+		rl.SetWindowMonitor(monitor)
+		rl.SetWindowSize(int(monitorWidth), int(monitorHeight))
+		rl.ToggleFullscreen()
+	}
+
+	c.displayMode = mode
+	c.monitor = monitor
 }
 
-func (c *Window) GetScreenBoundary() rl.Rectangle {
-	screenWidth, screenHeight := c.GetScreenDimensions()
-	screenRect := rl.NewRectangle(0, 0, screenWidth, screenHeight)
-	return screenRect
+// follow - keeps up with what the player did to the window: resizing it or dragging it to another monitor.
+// the canvas keeps its size, only the viewport it's drawn into changes
+func (c *Window) follow() {
+	if rl.IsWindowResized() && c.displayMode == Windowed {
+		c.width, c.height = int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight())
+	}
+
+	// the new monitor may have another dpi, raylib rescales the window and the viewport follows below
+	c.monitor = rl.GetCurrentMonitor()
+
+	c.updateViewport()
 }
 
-// GetScreenBoundaryLines - returns the boundary lines in the order of
-// TOP, LEFT, BOTTOM, RIGHT
-func (c *Window) GetScreenBoundaryLines(screenBoundary rl.Rectangle) [4][2]rl.Vector2 {
-	topLeft := rl.NewVector2(screenBoundary.X, screenBoundary.Y)
-	topRight := rl.NewVector2(screenBoundary.X+screenBoundary.Width, screenBoundary.Y)
-	bottomLeft := rl.NewVector2(screenBoundary.X, screenBoundary.Y+screenBoundary.Height)
-	bottomRight := rl.NewVector2(screenBoundary.X+screenBoundary.Width, screenBoundary.Y+screenBoundary.Height)
+// GetScreenDimensions - the size of the canvas everything is laid out on
+func (c *Window) GetScreenDimensions() (float32, float32) {
+	return CanvasWidth, CanvasHeight
+}
+
+// GetWindowDimensions - the actual pixels of the window
+func (c *Window) GetWindowDimensions() (float32, float32) {
+	return float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight())
+}
+
+// updateViewport - fits the canvas into the window with bars on the sides that don't match,
+// and makes the mouse report canvas coordinates
+func (c *Window) updateViewport() {
+	w, h := c.GetWindowDimensions()
+	scale := min(w/CanvasWidth, h/CanvasHeight)
+	// a minimized window has no size, the last viewport is kept until it's back
+	if scale <= 0 {
+		return
+	}
+
+	c.viewport = rl.NewRectangle(
+		(w-CanvasWidth*scale)/2,
+		(h-CanvasHeight*scale)/2,
+		CanvasWidth*scale,
+		CanvasHeight*scale,
+	)
+
+	setMouseOffset(rl.SetMouseOffset, -c.viewport.X, -c.viewport.Y)
+	rl.SetMouseScale(1/scale, 1/scale)
+}
 
-	lines := [4][2]rl.Vector2{
-		{topLeft, topRight},
-		{topLeft, bottomLeft},
-		{bottomLeft, bottomRight},
-		{topRight, bottomRight},
+// setMouseOffset - the cgo binding takes ints and the windows one without cgo takes int32s
+func setMouseOffset[T int | int32](set func(x, y T), x, y float32) {
+	set(T(x), T(y))
+}
+
+// toCanvas - converts a point in window pixels, like a touch, to the canvas
+func (c *Window) toCanvas(point rl.Vector2) rl.Vector2 {
+	scale := c.viewport.Width / CanvasWidth
+	if scale == 0 {
+		return point
 	}
+	return rl.NewVector2((point.X-c.viewport.X)/scale, (point.Y-c.viewport.Y)/scale)
+}
+
+// present - draws the canvas into the window
+func (c *Window) present() {
+	rl.ClearBackground(rl.Black)
+	// render textures are upside down
+	source := rl.NewRectangle(0, 0, CanvasWidth, -CanvasHeight)
+	rl.DrawTexturePro(c.canvas.Texture, source, c.viewport, rl.NewVector2(0, 0), 0, rl.White)
+}
 
-	return lines
+func (c *Window) GetScreenBoundary() rl.Rectangle {
+	screenWidth, screenHeight := c.GetScreenDimensions()
+	screenRect := rl.NewRectangle(0, 0, screenWidth, screenHeight)
+	return screenRect
 }
 
 func (c *Window) GetScreenDiagonal() float32 {